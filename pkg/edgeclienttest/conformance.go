@@ -0,0 +1,598 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package edgeclienttest certifies that an interfaces.EdgeClient implementation, such as a caching or proxying
+// wrapper of xrt.Client, behaves like xrt.Client. The suite runs against local fake XRT nodes on an in-memory
+// message bus, so it needs no broker:
+//
+//	func TestConformance(t *testing.T) {
+//		edgeclienttest.RunConformance(t, func(t *testing.T, env edgeclienttest.Environment) interfaces.EdgeClient {
+//			return mycache.Wrap(edgeclienttest.NewXrtClient(t, env))
+//		})
+//	}
+package edgeclienttest
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+const (
+	// DefaultResponseTimeout is the response timeout of the Environment
+	DefaultResponseTimeout = 2 * time.Second
+	// nodeCount is the number of XRT nodes answering component discovery
+	nodeCount = 2
+	// luaComponent is the component updated by UpdateLuaScript
+	luaComponent = "lua"
+)
+
+// Environment describes the local XRT setup the EdgeClient under test must connect to
+type Environment struct {
+	MessageBus   *xrttest.MessageBus
+	RequestTopic string
+	CommandTopic string
+	ReplyTopic   string
+	// DiscoveryTopic is the topic XRT publishes discovered devices to
+	DiscoveryTopic string
+	// ResponseTimeout is the response timeout the client should be created with
+	ResponseTimeout time.Duration
+	// Nodes are the fake XRT nodes. Nodes[0] answers every request, the others only answer component discovery.
+	Nodes []*xrttest.XRT
+	// Codec is the codec the nodes decode requests and encode replies with, the client must use the same
+	Codec codec.Codec
+	// SingleNode reports that the client under test addresses Nodes[0] only, see Options.SingleNode
+	SingleNode bool
+}
+
+// Options provides the config of the environments of the suite
+type Options struct {
+	// Codec is the codec of the fake XRT nodes, defaults to codec.JSON
+	Codec codec.Codec
+	// SingleNode makes the suite expect component discovery replies of Nodes[0] only, for clients addressing a single
+	// node like the node views of xrt.Client
+	SingleNode bool
+}
+
+// Factory creates the EdgeClient under test for the environment. The suite closes the client at the end of each test.
+type Factory func(t *testing.T, env Environment) interfaces.EdgeClient
+
+// environments makes the topics of every Environment unique, as clients share topic managers by topic
+var environments atomic.Uint64
+
+// NewXrtClient creates an xrt.Client for the environment, to be wrapped by the implementation under test
+func NewXrtClient(t *testing.T, env Environment) interfaces.EdgeClient {
+	t.Helper()
+	options := xrt.NewClientOptions(
+		xrt.NewCommandOptions(env.CommandTopic, "", nil),
+		xrt.NewDiscoveryOptions(env.DiscoveryTopic, nil, env.ResponseTimeout, nil, 0),
+		nil)
+	options.Codec = env.Codec
+	client, err := xrt.NewXrtClient(context.Background(), env.MessageBus, env.RequestTopic, env.ReplyTopic,
+		env.ResponseTimeout, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	return client
+}
+
+// RunConformance runs the conformance suite as subtests of t, each against a new environment and client
+func RunConformance(t *testing.T, factory Factory) {
+	RunConformanceWithOptions(t, factory, Options{})
+}
+
+// RunConformanceWithOptions runs the conformance suite like RunConformance, in environments configured by options
+func RunConformanceWithOptions(t *testing.T, factory Factory, options Options) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, env Environment, client interfaces.EdgeClient)
+	}{
+		{"DeviceRoundTrip", testDeviceRoundTrip},
+		{"DeviceErrors", testDeviceErrors},
+		{"DiscoveredDevice", testDiscoveredDevice},
+		{"DeviceResources", testDeviceResources},
+		{"Scan", testScan},
+		{"ProfileRoundTrip", testProfileRoundTrip},
+		{"ProfileErrors", testProfileErrors},
+		{"ScheduleRoundTrip", testScheduleRoundTrip},
+		{"ScheduleErrors", testScheduleErrors},
+		{"Components", testComponents},
+		{"ComponentDiscovery", testComponentDiscovery},
+		{"ComponentDiscoveryStream", testComponentDiscoveryStream},
+		{"TriggerDiscovery", testTriggerDiscovery},
+		{"ContextCancellation", testContextCancellation},
+		{"Timeout", testTimeout},
+		{"Readiness", testReadiness},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newEnvironment(t, options)
+			client := factory(t, env)
+			if client == nil {
+				t.Fatal("factory returned a nil client")
+			}
+			t.Cleanup(func() {
+				if err := client.Close(); err != nil {
+					t.Errorf("Close failed: %v", err)
+				}
+			})
+			tt.test(t, env, client)
+		})
+	}
+}
+
+func newEnvironment(t *testing.T, options Options) Environment {
+	t.Helper()
+	prefix := fmt.Sprintf("edgeclienttest/%d", environments.Add(1))
+	env := Environment{
+		MessageBus:      xrttest.NewMessageBus(),
+		RequestTopic:    prefix + "/request",
+		CommandTopic:    prefix + "/command",
+		ReplyTopic:      prefix + "/reply",
+		DiscoveryTopic:  prefix + "/discovery",
+		ResponseTimeout: DefaultResponseTimeout,
+		Codec:           codec.OrDefault(options.Codec),
+		SingleNode:      options.SingleNode,
+	}
+	for i := range nodeCount {
+		nodeOptions := xrttest.Options{NodeID: fmt.Sprintf("node-%d", i+1), RequestTopic: env.RequestTopic, ReplyTopic: env.ReplyTopic, Codec: env.Codec}
+		if i == 0 {
+			nodeOptions.CommandTopic = env.CommandTopic
+		}
+		node := xrttest.NewXRT(env.MessageBus, nodeOptions)
+		if i > 0 {
+			dropAllButDiscovery(node)
+		}
+		node.AddComponent(xrttest.Component{Name: fmt.Sprintf("mqtt-%d", i+1), Category: "north"})
+		if err := node.Start(); err != nil {
+			t.Fatalf("failed to start fake XRT node: %v", err)
+		}
+		t.Cleanup(node.Stop)
+		env.Nodes = append(env.Nodes, node)
+	}
+	env.Nodes[0].AddComponent(xrttest.Component{Name: luaComponent, Category: "transform"})
+	return env
+}
+
+// dropAllButDiscovery makes a secondary node ignore every request but component discovery, so single-reply requests
+// get exactly one reply
+func dropAllButDiscovery(node *xrttest.XRT) {
+	for _, op := range []string{
+		xrttest.OpDeviceList, xrttest.OpDeviceGet, xrttest.OpDeviceAdd, xrttest.OpDeviceUpdate, xrttest.OpDeviceDelete,
+		xrttest.OpDeviceAddDiscovered, xrttest.OpDeviceScan, xrttest.OpDeviceRead, xrttest.OpDeviceWrite,
+		xrttest.OpDiscoveryTrigger, xrttest.OpProfileList, xrttest.OpProfileGet, xrttest.OpProfileAdd,
+		xrttest.OpProfileUpdate, xrttest.OpProfileDelete, xrttest.OpScheduleList, xrttest.OpScheduleRead,
+		xrttest.OpScheduleAdd, xrttest.OpScheduleUpdate, xrttest.OpScheduleDelete, xrttest.OpComponentUpdate,
+	} {
+		node.SetFault(op, xrttest.Fault{Drop: true})
+	}
+}
+
+func testDeviceRoundTrip(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	mustAddProfile(t, client, "profile-a")
+	mustAddProfile(t, client, "profile-b")
+
+	if err := client.AddDevice(ctx, dtos.Device{Name: "device-1", ProfileName: "profile-a"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	devices, err := client.AllDevices(ctx)
+	if err != nil {
+		t.Fatalf("AllDevices failed: %v", err)
+	}
+	if len(devices) != 1 || devices[0] != "device-1" {
+		t.Fatalf("AllDevices returned %v, want [device-1]", devices)
+	}
+	device, err := client.DeviceByName(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("DeviceByName failed: %v", err)
+	}
+	expectField(t, "DeviceByName", device, "profileName", "profile-a")
+
+	if err = client.UpdateDevice(ctx, dtos.Device{Name: "device-1", ProfileName: "profile-b"}); err != nil {
+		t.Fatalf("UpdateDevice failed: %v", err)
+	}
+	device, err = client.DeviceByName(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("DeviceByName failed after update: %v", err)
+	}
+	expectField(t, "DeviceByName after update", device, "profileName", "profile-b")
+
+	if err = client.DeleteDeviceByName(ctx, "device-1"); err != nil {
+		t.Fatalf("DeleteDeviceByName failed: %v", err)
+	}
+	if _, ok := env.Nodes[0].Device("device-1"); ok {
+		t.Fatal("device-1 still exists on the XRT node after DeleteDeviceByName")
+	}
+	_, err = client.DeviceByName(ctx, "device-1")
+	expectKind(t, "DeviceByName after delete", err, errors.KindEntityDoesNotExist)
+}
+
+func testDeviceErrors(t *testing.T, _ Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	mustAddProfile(t, client, "profile-a")
+
+	_, err := client.DeviceByName(ctx, "missing")
+	expectKind(t, "DeviceByName", err, errors.KindEntityDoesNotExist)
+	err = client.UpdateDevice(ctx, dtos.Device{Name: "missing", ProfileName: "profile-a"})
+	expectKind(t, "UpdateDevice", err, errors.KindEntityDoesNotExist)
+	err = client.DeleteDeviceByName(ctx, "missing")
+	expectKind(t, "DeleteDeviceByName", err, errors.KindEntityDoesNotExist)
+
+	if err = client.AddDevice(ctx, dtos.Device{Name: "device-1", ProfileName: "profile-a"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	err = client.AddDevice(ctx, dtos.Device{Name: "device-1", ProfileName: "profile-a"})
+	expectKind(t, "AddDevice of an existing device", err, errors.KindDuplicateName)
+	err = client.AddDevice(ctx, dtos.Device{Name: "device-2", ProfileName: "missing"})
+	expectKind(t, "AddDevice with an unknown profile", err, errors.KindContractInvalid)
+}
+
+func testDiscoveredDevice(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	if err := client.AddDiscoveredDevice(context.Background(), dtos.Device{Name: "discovered-1"}); err != nil {
+		t.Fatalf("AddDiscoveredDevice failed: %v", err)
+	}
+	if _, ok := env.Nodes[0].Device("discovered-1"); !ok {
+		t.Fatal("discovered-1 doesn't exist on the XRT node after AddDiscoveredDevice")
+	}
+}
+
+func testDeviceResources(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	mustAddProfile(t, client, "profile-a")
+	if err := client.AddDevice(ctx, dtos.Device{Name: "device-1", ProfileName: "profile-a"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+
+	if err := client.WriteDeviceResources(ctx, "device-1", map[string]any{"temperature": 21.5}, nil); err != nil {
+		t.Fatalf("WriteDeviceResources failed: %v", err)
+	}
+	if value, _ := env.Nodes[0].ResourceValue("device-1", "temperature"); value != 21.5 {
+		t.Fatalf("XRT node holds temperature %v after WriteDeviceResources, want 21.5", value)
+	}
+	result, err := client.ReadDeviceResources(ctx, "device-1", []string{"temperature"})
+	if err != nil {
+		t.Fatalf("ReadDeviceResources failed: %v", err)
+	}
+	readings, _ := fieldsOf(t, result)["readings"].(map[string]any)
+	if readings["temperature"] != 21.5 {
+		t.Fatalf("ReadDeviceResources returned readings %v, want temperature 21.5", readings)
+	}
+
+	_, err = client.ReadDeviceResources(ctx, "device-1", []string{"missing"})
+	expectKind(t, "ReadDeviceResources of an unknown resource", err, errors.KindEntityDoesNotExist)
+	err = client.WriteDeviceResources(ctx, "missing", map[string]any{"temperature": 1}, nil)
+	expectKind(t, "WriteDeviceResources of an unknown device", err, errors.KindEntityDoesNotExist)
+}
+
+func testScan(t *testing.T, _ Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	device := dtos.Device{Name: "device-1", ProfileName: "scanned-profile"}
+	profileName, err := client.ScanDeviceWithResult(ctx, device, nil, DefaultResponseTimeout)
+	if err != nil {
+		t.Fatalf("ScanDeviceWithResult failed: %v", err)
+	}
+	if profileName != "scanned-profile" {
+		t.Fatalf("ScanDeviceWithResult returned profile %q, want scanned-profile", profileName)
+	}
+	if err = client.ScanDevice(ctx, device, map[string]any{"depth": 1}, DefaultResponseTimeout); err != nil {
+		t.Fatalf("ScanDevice failed: %v", err)
+	}
+}
+
+func testProfileRoundTrip(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	mustAddProfile(t, client, "profile-a")
+
+	profiles, err := client.AllDeviceProfiles(ctx)
+	if err != nil {
+		t.Fatalf("AllDeviceProfiles failed: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0] != "profile-a" {
+		t.Fatalf("AllDeviceProfiles returned %v, want [profile-a]", profiles)
+	}
+	profile, err := client.DeviceProfileByName(ctx, "profile-a")
+	if err != nil {
+		t.Fatalf("DeviceProfileByName failed: %v", err)
+	}
+	if profile.Name != "profile-a" {
+		t.Fatalf("DeviceProfileByName returned profile %q, want profile-a", profile.Name)
+	}
+
+	profile.Manufacturer = "IOTech"
+	if err = client.UpdateDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("UpdateDeviceProfile failed: %v", err)
+	}
+	profile, err = client.DeviceProfileByName(ctx, "profile-a")
+	if err != nil {
+		t.Fatalf("DeviceProfileByName failed after update: %v", err)
+	}
+	if profile.Manufacturer != "IOTech" {
+		t.Fatalf("DeviceProfileByName returned manufacturer %q after update, want IOTech", profile.Manufacturer)
+	}
+
+	if err = client.DeleteDeviceProfileByName(ctx, "profile-a"); err != nil {
+		t.Fatalf("DeleteDeviceProfileByName failed: %v", err)
+	}
+	if names := env.Nodes[0].ProfileNames(); len(names) != 0 {
+		t.Fatalf("XRT node holds profiles %v after DeleteDeviceProfileByName", names)
+	}
+}
+
+func testProfileErrors(t *testing.T, _ Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	_, err := client.DeviceProfileByName(ctx, "missing")
+	expectKind(t, "DeviceProfileByName", err, errors.KindEntityDoesNotExist)
+	err = client.UpdateDeviceProfile(ctx, dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "missing"}})
+	expectKind(t, "UpdateDeviceProfile", err, errors.KindEntityDoesNotExist)
+	err = client.DeleteDeviceProfileByName(ctx, "missing")
+	expectKind(t, "DeleteDeviceProfileByName", err, errors.KindEntityDoesNotExist)
+
+	mustAddProfile(t, client, "profile-a")
+	err = client.AddDeviceProfile(ctx, dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile-a"}})
+	expectKind(t, "AddDeviceProfile of an existing profile", err, errors.KindDuplicateName)
+
+	if err = client.AddDevice(ctx, dtos.Device{Name: "device-1", ProfileName: "profile-a"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	err = client.DeleteDeviceProfileByName(ctx, "profile-a")
+	expectKind(t, "DeleteDeviceProfileByName of a profile in use", err, errors.KindStatusConflict)
+}
+
+func testScheduleRoundTrip(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	if err := client.AddSchedule(ctx, xrtmodels.Schedule{Name: "schedule-1"}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	schedules, err := client.AllSchedules(ctx)
+	if err != nil {
+		t.Fatalf("AllSchedules failed: %v", err)
+	}
+	if len(schedules) != 1 || schedules[0] != "schedule-1" {
+		t.Fatalf("AllSchedules returned %v, want [schedule-1]", schedules)
+	}
+	schedule, err := client.ScheduleByName(ctx, "schedule-1")
+	if err != nil {
+		t.Fatalf("ScheduleByName failed: %v", err)
+	}
+	if schedule.Name != "schedule-1" {
+		t.Fatalf("ScheduleByName returned schedule %q, want schedule-1", schedule.Name)
+	}
+	if err = client.UpdateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("UpdateSchedule failed: %v", err)
+	}
+	if err = client.DeleteScheduleByName(ctx, "schedule-1"); err != nil {
+		t.Fatalf("DeleteScheduleByName failed: %v", err)
+	}
+	if names := env.Nodes[0].ScheduleNames(); len(names) != 0 {
+		t.Fatalf("XRT node holds schedules %v after DeleteScheduleByName", names)
+	}
+}
+
+func testScheduleErrors(t *testing.T, _ Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	_, err := client.ScheduleByName(ctx, "missing")
+	expectKind(t, "ScheduleByName", err, errors.KindEntityDoesNotExist)
+	err = client.UpdateSchedule(ctx, xrtmodels.Schedule{Name: "missing"})
+	expectKind(t, "UpdateSchedule", err, errors.KindEntityDoesNotExist)
+	err = client.DeleteScheduleByName(ctx, "missing")
+	expectKind(t, "DeleteScheduleByName", err, errors.KindEntityDoesNotExist)
+
+	if err = client.AddSchedule(ctx, xrtmodels.Schedule{Name: "schedule-1"}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	err = client.AddSchedule(ctx, xrtmodels.Schedule{Name: "schedule-1"})
+	expectKind(t, "AddSchedule of an existing schedule", err, errors.KindDuplicateName)
+}
+
+func testComponents(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	ctx := context.Background()
+	if err := client.UpdateLuaScript(ctx, "return value"); err != nil {
+		t.Fatalf("UpdateLuaScript failed: %v", err)
+	}
+	if component, _ := env.Nodes[0].Component(luaComponent); component.Config["Script"] != "return value" {
+		t.Fatalf("lua component has config %v after UpdateLuaScript", component.Config)
+	}
+	if err := client.UpdateComponent(ctx, "mqtt-1", map[string]any{"Port": 1883.0}); err != nil {
+		t.Fatalf("UpdateComponent failed: %v", err)
+	}
+	if component, _ := env.Nodes[0].Component("mqtt-1"); component.Config["Port"] != 1883.0 {
+		t.Fatalf("mqtt-1 component has config %v after UpdateComponent", component.Config)
+	}
+	err := client.UpdateComponent(ctx, "missing", map[string]any{"Port": 1883.0})
+	expectKind(t, "UpdateComponent of an unknown component", err, errors.KindEntityDoesNotExist)
+}
+
+// answeringNodes returns the number of nodes expected to answer component discovery
+func (env Environment) answeringNodes() int {
+	if env.SingleNode {
+		return 1
+	}
+	return len(env.Nodes)
+}
+
+func testComponentDiscovery(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	subscribeTimeout := 500 * time.Millisecond
+	replies, err := client.DiscoverComponents(context.Background(), "north", subscribeTimeout)
+	if err != nil {
+		t.Fatalf("DiscoverComponents failed: %v", err)
+	}
+	if len(replies) != env.answeringNodes() {
+		t.Fatalf("DiscoverComponents returned %d replies, want one per node (%d)", len(replies), env.answeringNodes())
+	}
+	nodes := make(map[any]bool)
+	for _, reply := range replies {
+		result, _ := fieldsOf(t, reply)["result"].(map[string]any)
+		nodes[result["node"]] = true
+		if components, _ := result["components"].([]any); len(components) != 1 {
+			t.Fatalf("DiscoverComponents returned components %v for category north, want one per node", result["components"])
+		}
+	}
+	if len(nodes) != env.answeringNodes() {
+		t.Fatalf("DiscoverComponents returned replies of nodes %v, want %d different nodes", nodes, env.answeringNodes())
+	}
+}
+
+// componentStreamer is implemented by clients streaming the component discovery replies, like xrt.Client
+type componentStreamer interface {
+	DiscoverComponentsStream(ctx context.Context, category string, subscribeTimeout time.Duration,
+		stop func(received int, reply xrtmodels.MultiComponentsResponse) bool) (<-chan xrtmodels.MultiComponentsResponse, errors.EdgeX)
+}
+
+func testComponentDiscoveryStream(t *testing.T, _ Environment, client interfaces.EdgeClient) {
+	streamer, ok := client.(componentStreamer)
+	if !ok {
+		t.Skip("client doesn't implement DiscoverComponentsStream")
+	}
+	subscribeTimeout := 5 * time.Second
+	start := time.Now()
+	stream, err := streamer.DiscoverComponentsStream(context.Background(), "", subscribeTimeout,
+		func(received int, _ xrtmodels.MultiComponentsResponse) bool { return received >= 1 })
+	if err != nil {
+		t.Fatalf("DiscoverComponentsStream failed: %v", err)
+	}
+	received := 0
+	for range stream {
+		received++
+	}
+	if received != 1 {
+		t.Fatalf("DiscoverComponentsStream sent %d replies, want 1 as the stop condition ends the stream", received)
+	}
+	if elapsed := time.Since(start); elapsed >= subscribeTimeout {
+		t.Fatalf("DiscoverComponentsStream ended after %v, the stop condition should end it before the subscribe timeout", elapsed)
+	}
+}
+
+func testTriggerDiscovery(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	if err := client.TriggerDiscovery(context.Background()); err != nil {
+		t.Fatalf("TriggerDiscovery failed: %v", err)
+	}
+	for _, request := range env.Nodes[0].Requests() {
+		if request.Op == xrttest.OpDiscoveryTrigger {
+			return
+		}
+	}
+	t.Fatal("XRT node didn't receive a discovery:trigger request")
+}
+
+func testContextCancellation(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.AllDevices(cancelled)
+	if !stdErrors.Is(err, context.Canceled) {
+		t.Fatalf("AllDevices with a cancelled context returned %v, want an error wrapping context.Canceled", err)
+	}
+
+	env.Nodes[0].SetFault(xrttest.OpDeviceGet, xrttest.Fault{Delay: DefaultResponseTimeout / 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = client.DeviceByName(ctx, "device-1")
+	if !stdErrors.Is(err, context.Canceled) {
+		t.Fatalf("DeviceByName cancelled in flight returned %v, want an error wrapping context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed >= DefaultResponseTimeout/2 {
+		t.Fatalf("DeviceByName returned %v after the cancellation, it should return as soon as the context is cancelled", elapsed)
+	}
+}
+
+func testTimeout(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	responseTimeout := 200 * time.Millisecond
+	client.SetResponseTimeout(responseTimeout)
+	env.Nodes[0].SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true})
+
+	start := time.Now()
+	_, err := client.AllDevices(context.Background())
+	expectKind(t, "AllDevices without a reply", err, xrt.KindTimeout)
+	if elapsed := time.Since(start); elapsed >= DefaultResponseTimeout {
+		t.Fatalf("AllDevices returned after %v, it should time out after the response timeout of %v", elapsed, responseTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client.SetResponseTimeout(DefaultResponseTimeout)
+	_, err = client.AllDevices(ctx)
+	if !stdErrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AllDevices with an expired deadline returned %v, want an error wrapping context.DeadlineExceeded", err)
+	}
+}
+
+func testReadiness(t *testing.T, env Environment, client interfaces.EdgeClient) {
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	health := client.Health()
+	if !health.Subscribed || health.LastRoundTrip.IsZero() {
+		t.Fatalf("Health after a successful Ping returned %+v, want an active subscription and a last round-trip", health)
+	}
+
+	client.SetResponseTimeout(100 * time.Millisecond)
+	env.Nodes[0].SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true, Count: 2})
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultResponseTimeout)
+	defer cancel()
+	if err := client.WaitReady(ctx); err != nil {
+		t.Fatalf("WaitReady failed once XRT replied again: %v", err)
+	}
+	if health = client.Health(); health.LastError == "" || health.LastErrorTime.IsZero() {
+		t.Fatalf("Health after unanswered pings returned %+v, want the last error", health)
+	}
+
+	env.Nodes[0].SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true})
+	ctx, cancel = context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := client.WaitReady(ctx); err == nil {
+		t.Fatal("WaitReady succeeded although XRT never replied")
+	}
+}
+
+func mustAddProfile(t *testing.T, client interfaces.EdgeClient, name string) {
+	t.Helper()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: name}}
+	if err := client.AddDeviceProfile(context.Background(), profile); err != nil {
+		t.Fatalf("AddDeviceProfile %s failed: %v", name, err)
+	}
+}
+
+func expectKind(t *testing.T, call string, err errors.EdgeX, kind errors.ErrKind) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("%s succeeded, want an error of kind %s", call, kind)
+	}
+	if errors.Kind(err) != kind {
+		t.Fatalf("%s returned an error of kind %s, want %s: %v", call, errors.Kind(err), kind, err)
+	}
+}
+
+// expectField checks a field of the JSON encoding of value, which doesn't depend on the Go field names of xrtmodels
+func expectField(t *testing.T, call string, value any, field string, expected any) {
+	t.Helper()
+	if actual := fieldsOf(t, value)[field]; actual != expected {
+		t.Fatalf("%s returned %s %v, want %v", call, field, actual, expected)
+	}
+}
+
+func fieldsOf(t *testing.T, value any) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", value, err)
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("failed to decode %T: %v", value, err)
+	}
+	return fields
+}
@@ -0,0 +1,16 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package interfaces
+
+import "time"
+
+// Health is a snapshot of the connectivity of an EdgeClient, e.g. for liveness probes
+type Health struct {
+	// Subscribed reports whether the subscription receiving the XRT replies is active
+	Subscribed bool `json:"subscribed"`
+	// LastRoundTrip is when XRT last replied to a request of the client, zero if it never did
+	LastRoundTrip time.Time `json:"last_round_trip"`
+	// LastError is the last request failure which wasn't reported by XRT itself, e.g. a timeout
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorTime time.Time `json:"last_error_time"`
+}
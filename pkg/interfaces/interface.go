@@ -43,6 +43,13 @@ type EdgeClient interface {
 
 	TriggerDiscovery(ctx context.Context) errors.EdgeX
 
+	// Ping checks that the client is subscribed to the replies and that XRT answers a lightweight request
+	Ping(ctx context.Context) errors.EdgeX
+	// WaitReady pings XRT with backoff until it succeeds or ctx is done
+	WaitReady(ctx context.Context) errors.EdgeX
+	// Health returns a snapshot of the connectivity of the client
+	Health() Health
+
 	// SetResponseTimeout sets responseTimeout to XrtClient
 	SetResponseTimeout(responseTimeout time.Duration)
 
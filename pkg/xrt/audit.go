@@ -0,0 +1,168 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// redactedValue replaces the values of redacted request fields
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are the request fields redacted by an AuditLog without RedactedFields
+var DefaultRedactedFields = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "privatekey", "private_key"}
+
+// AuditRecord describes a mutating XRT operation sent by the client
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+	// Caller identifies who issued the operation, see WithCaller
+	Caller    string `json:"caller,omitempty"`
+	Op        string `json:"op"`
+	Topic     string `json:"topic"`
+	RequestId string `json:"request_id"`
+	// Attempts are the requestIds of all attempts of a retried operation, starting with RequestId
+	Attempts []string `json:"attempts,omitempty"`
+	// Targets are the names of the entities the operation changes, keyed by entity kind, e.g. device or profile
+	Targets map[string]string `json:"targets,omitempty"`
+	// Request is the request payload with the secrets redacted
+	Request map[string]any `json:"request,omitempty"`
+	// Outcome is OutcomeOK or the EdgeX error kind of the failure
+	Outcome string `json:"outcome"`
+	// Status is the status of the error result returned by XRT, if any
+	Status  int           `json:"status,omitempty"`
+	Error   string        `json:"error,omitempty"`
+	Latency time.Duration `json:"latency_ns"`
+	// DryRun is set for operations of a client in dry-run mode, which were recorded rather than sent to XRT
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// AuditSink stores AuditRecords. Implementations must be safe for concurrent use.
+type AuditSink interface {
+	WriteAudit(record AuditRecord) errors.EdgeX
+}
+
+// AuditLog emits an AuditRecord to every sink once a mutating operation, see IsMutatingOp, has completed. Retried
+// operations are recorded once, with the requestIds and the latency of all attempts.
+// Sinks are called on the caller's goroutine; failing sinks are logged and don't fail the operation.
+type AuditLog struct {
+	Sinks []AuditSink
+	// RedactedFields are the request fields whose values are redacted, matched case-insensitively against any part of
+	// the field name at any depth. Defaults to DefaultRedactedFields.
+	RedactedFields []string
+	// Caller returns the identity of the caller from the context of the operation, defaults to CallerFrom
+	Caller func(ctx context.Context) string
+}
+
+// NewAuditLog creates an AuditLog writing to the given sinks
+func NewAuditLog(sinks ...AuditSink) *AuditLog {
+	return &AuditLog{Sinks: sinks}
+}
+
+type callerContextKey struct{}
+
+// WithCaller returns a context identifying the caller of the operations sent with it, e.g. a user or service name
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFrom returns the caller set with WithCaller, or an empty string
+func CallerFrom(ctx context.Context) string {
+	caller, _ := ctx.Value(callerContextKey{}).(string)
+	return caller
+}
+
+// callerOf returns the caller of the operation sent with ctx as identified by the AuditLog of the client
+func (c *Client) callerOf(ctx context.Context) string {
+	if c.clientOptions != nil && c.clientOptions.AuditLog != nil && c.clientOptions.AuditLog.Caller != nil {
+		return c.clientOptions.AuditLog.Caller(ctx)
+	}
+	return CallerFrom(ctx)
+}
+
+// audit emits the AuditRecord of the operation started at start to the AuditLog of the client, if any
+func (c *Client) audit(caller string, op string, requestTopic string, requestId string, attempts []string, request any, start time.Time, err errors.EdgeX) {
+	if c.clientOptions == nil || c.clientOptions.AuditLog == nil || !IsMutatingOp(op) {
+		return
+	}
+	auditLog := c.clientOptions.AuditLog
+
+	fields := requestFields(request)
+	record := AuditRecord{
+		Time:      start,
+		Caller:    caller,
+		Op:        op,
+		Topic:     requestTopic,
+		RequestId: requestId,
+		Attempts:  attempts,
+		Targets:   targetsOf(fields),
+		Request:   auditLog.redact(fields),
+		Outcome:   OutcomeOK,
+		Latency:   time.Since(start),
+		DryRun:    c.dryRunEnabled(),
+	}
+	if err != nil {
+		record.Outcome = string(errors.Kind(err))
+		record.Error = err.Error()
+		var xrtErr *XRTError
+		if stdErrors.As(err, &xrtErr) {
+			record.Status = xrtErr.Status
+		}
+	}
+
+	for _, sink := range auditLog.Sinks {
+		if sinkErr := sink.WriteAudit(record); sinkErr != nil {
+			c.lc.Errorf("failed to write the audit record of XRT request %s, requestId: %s: %v", op, requestId, sinkErr)
+		}
+	}
+}
+
+// redact returns a copy of the request fields with the values of the redacted fields replaced
+func (a *AuditLog) redact(fields map[string]any) map[string]any {
+	redactedFields := a.RedactedFields
+	if redactedFields == nil {
+		redactedFields = DefaultRedactedFields
+	}
+	redacted, _ := redactValue(fields, redactedFields).(map[string]any)
+	return redacted
+}
+
+func redactValue(value any, redactedFields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return nil
+		}
+		redacted := make(map[string]any, len(v))
+		for key, fieldValue := range v {
+			if isRedactedField(key, redactedFields) {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = redactValue(fieldValue, redactedFields)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item, redactedFields)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func isRedactedField(key string, redactedFields []string) bool {
+	key = strings.ToLower(key)
+	for _, field := range redactedFields {
+		if strings.Contains(key, strings.ToLower(field)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// BreakerState is the state of the circuit of a request topic
+type BreakerState int
+
+const (
+	// BreakerClosed lets requests pass
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects requests with ErrCircuitOpen until the cool-down has passed
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe request pass, which decides whether the circuit closes or opens again
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// CircuitBreaker stops sending requests to a request topic whose XRT node doesn't reply. The circuit of the topic
+// opens after FailureThreshold consecutive response timeouts or publish failures; any reply, including an error
+// result, closes it.
+// It only covers requests answered by a single node.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures opening the circuit
+	FailureThreshold int
+	// CoolDown is how long the circuit stays open before a probe request may pass
+	CoolDown time.Duration
+	// OnStateChange is called after the circuit of a topic changed its state, it must not block
+	OnStateChange func(topic string, from BreakerState, to BreakerState)
+}
+
+// NewCircuitBreaker creates a CircuitBreaker opening after failureThreshold consecutive timeouts for coolDown
+func NewCircuitBreaker(failureThreshold int, coolDown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		CoolDown:         coolDown,
+	}
+}
+
+// breaker keeps the circuits of the request topics of a client
+type breaker struct {
+	config CircuitBreaker
+
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// stateChange is a transition to report through OnStateChange
+type stateChange struct {
+	from BreakerState
+	to   BreakerState
+}
+
+func newBreaker(config CircuitBreaker) *breaker {
+	return &breaker{config: config, circuits: make(map[string]*circuit)}
+}
+
+// allow reports whether a request may be published to the topic and returns the func recording its outcome, which
+// must be called once the reply arrived or the request failed. A nil breaker allows every request.
+func (b *breaker) allow(topic string) (func(err errors.EdgeX), errors.EdgeX) {
+	if b == nil {
+		return func(errors.EdgeX) {}, nil
+	}
+
+	b.mutex.Lock()
+	c := b.circuit(topic)
+	var change *stateChange
+	switch c.state {
+	case BreakerOpen:
+		if time.Since(c.openedAt) < b.config.CoolDown {
+			b.mutex.Unlock()
+			return nil, b.openError(topic)
+		}
+		change = c.transition(BreakerHalfOpen)
+		c.probing = true
+	case BreakerHalfOpen:
+		if c.probing {
+			b.mutex.Unlock()
+			return nil, b.openError(topic)
+		}
+		c.probing = true
+	}
+	b.mutex.Unlock()
+	b.report(topic, change)
+
+	return func(err errors.EdgeX) {
+		b.record(topic, err)
+	}, nil
+}
+
+// record updates the circuit of the topic with the outcome of a request
+func (b *breaker) record(topic string, err errors.EdgeX) {
+	b.mutex.Lock()
+	c := b.circuit(topic)
+	var change *stateChange
+	switch {
+	case stdErrors.Is(err, context.Canceled) || stdErrors.Is(err, context.DeadlineExceeded) || stdErrors.Is(err, ErrLimitExceeded):
+		// the caller gave up or the request wasn't sent, which says nothing about the node
+		c.probing = false
+	case stdErrors.Is(err, ErrTimeout) || errors.Kind(err) == errors.KindCommunicationError:
+		c.probing = false
+		if c.state == BreakerHalfOpen {
+			c.openedAt = time.Now()
+			change = c.transition(BreakerOpen)
+			break
+		}
+		c.failures++
+		if c.state == BreakerClosed && c.failures >= max(b.config.FailureThreshold, 1) {
+			c.openedAt = time.Now()
+			change = c.transition(BreakerOpen)
+		}
+	default:
+		c.failures = 0
+		c.probing = false
+		if c.state != BreakerClosed {
+			change = c.transition(BreakerClosed)
+		}
+	}
+	b.mutex.Unlock()
+	b.report(topic, change)
+}
+
+// state returns the state of the circuit of the topic
+func (b *breaker) state(topic string) BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if c, ok := b.circuits[topic]; ok {
+		return c.state
+	}
+	return BreakerClosed
+}
+
+func (b *breaker) circuit(topic string) *circuit {
+	c, ok := b.circuits[topic]
+	if !ok {
+		c = &circuit{}
+		b.circuits[topic] = c
+	}
+	return c
+}
+
+func (b *breaker) openError(topic string) errors.EdgeX {
+	return errors.NewCommonEdgeX(KindCircuitOpen, fmt.Sprintf("circuit of topic %s is open", topic), ErrCircuitOpen)
+}
+
+func (b *breaker) report(topic string, change *stateChange) {
+	if change != nil && b.config.OnStateChange != nil {
+		b.config.OnStateChange(topic, change.from, change.to)
+	}
+}
+
+func (c *circuit) transition(to BreakerState) *stateChange {
+	change := &stateChange{from: c.state, to: to}
+	c.state = to
+	if to != BreakerClosed {
+		c.failures = 0
+	}
+	return change
+}
+
+// CircuitState returns the state of the circuit breaker of the request topic, which is BreakerClosed if the client
+// has no CircuitBreaker
+func (c *Client) CircuitState(topic string) BreakerState {
+	return c.breaker.state(topic)
+}
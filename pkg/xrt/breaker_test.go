@@ -0,0 +1,162 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+const (
+	breakerTopic    = "breaker/request"
+	breakerCoolDown = 20 * time.Millisecond
+)
+
+// stateRecorder records the changes reported through OnStateChange
+type stateRecorder struct {
+	mutex   sync.Mutex
+	changes []stateChange
+}
+
+func (r *stateRecorder) onStateChange(topic string, from BreakerState, to BreakerState) {
+	if topic != breakerTopic {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.changes = append(r.changes, stateChange{from: from, to: to})
+}
+
+func (r *stateRecorder) assert(t *testing.T, want ...stateChange) {
+	t.Helper()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !slices.Equal(r.changes, want) {
+		t.Fatalf("reported state changes %v, want %v", r.changes, want)
+	}
+}
+
+func newTestBreaker(recorder *stateRecorder) *breaker {
+	return newBreaker(CircuitBreaker{FailureThreshold: 2, CoolDown: breakerCoolDown, OnStateChange: recorder.onStateChange})
+}
+
+var (
+	errTestTimeout = errors.NewCommonEdgeX(KindTimeout, "", ErrTimeout)
+	errTestResult  = newXRTError(StatusNotFound, "not found", OpDeviceGet, "")
+)
+
+// send runs a request through the breaker, ending with err
+func send(t *testing.T, b *breaker, err errors.EdgeX) {
+	t.Helper()
+	done, allowErr := b.allow(breakerTopic)
+	if allowErr != nil {
+		t.Fatalf("request rejected: %v", allowErr)
+	}
+	done(err)
+}
+
+func assertOpen(t *testing.T, b *breaker) {
+	t.Helper()
+	_, err := b.allow(breakerTopic)
+	if !stdErrors.Is(err, ErrCircuitOpen) || errors.Kind(err) != KindCircuitOpen {
+		t.Fatalf("allow returned %v of kind %s, want ErrCircuitOpen", err, errors.Kind(err))
+	}
+	if errors.Kind(err) == KindTimeout {
+		t.Fatalf("rejection %v has the kind of a timeout", err)
+	}
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	recorder := &stateRecorder{}
+	b := newTestBreaker(recorder)
+
+	send(t, b, errTestTimeout)
+	send(t, b, errTestResult)
+	send(t, b, errTestTimeout)
+	if state := b.state(breakerTopic); state != BreakerClosed {
+		t.Fatalf("circuit is %s after non-consecutive failures, want closed", state)
+	}
+	send(t, b, errTestTimeout)
+	if state := b.state(breakerTopic); state != BreakerOpen {
+		t.Fatalf("circuit is %s after consecutive failures, want open", state)
+	}
+	assertOpen(t, b)
+	recorder.assert(t, stateChange{from: BreakerClosed, to: BreakerOpen})
+}
+
+func TestBreakerHalfOpenSingleProbe(t *testing.T) {
+	recorder := &stateRecorder{}
+	b := newTestBreaker(recorder)
+	send(t, b, errTestTimeout)
+	send(t, b, errTestTimeout)
+	time.Sleep(breakerCoolDown)
+
+	probe, err := b.allow(breakerTopic)
+	if err != nil {
+		t.Fatalf("probe rejected after the cool-down: %v", err)
+	}
+	if state := b.state(breakerTopic); state != BreakerHalfOpen {
+		t.Fatalf("circuit is %s while probing, want half-open", state)
+	}
+	assertOpen(t, b)
+
+	probe(errTestResult)
+	if state := b.state(breakerTopic); state != BreakerClosed {
+		t.Fatalf("circuit is %s after a successful probe, want closed", state)
+	}
+	send(t, b, nil)
+	recorder.assert(t,
+		stateChange{from: BreakerClosed, to: BreakerOpen},
+		stateChange{from: BreakerOpen, to: BreakerHalfOpen},
+		stateChange{from: BreakerHalfOpen, to: BreakerClosed})
+}
+
+func TestBreakerFailedProbeReopens(t *testing.T) {
+	recorder := &stateRecorder{}
+	b := newTestBreaker(recorder)
+	send(t, b, errTestTimeout)
+	send(t, b, errTestTimeout)
+	time.Sleep(breakerCoolDown)
+
+	send(t, b, errors.NewCommonEdgeX(errors.KindCommunicationError, "", ErrPublishFailed))
+	if state := b.state(breakerTopic); state != BreakerOpen {
+		t.Fatalf("circuit is %s after a failed probe, want open", state)
+	}
+	assertOpen(t, b)
+	recorder.assert(t,
+		stateChange{from: BreakerClosed, to: BreakerOpen},
+		stateChange{from: BreakerOpen, to: BreakerHalfOpen},
+		stateChange{from: BreakerHalfOpen, to: BreakerOpen})
+}
+
+func TestBreakerAbandonedProbe(t *testing.T) {
+	recorder := &stateRecorder{}
+	b := newTestBreaker(recorder)
+	send(t, b, errTestTimeout)
+	send(t, b, errTestTimeout)
+	time.Sleep(breakerCoolDown)
+
+	// a cancelled probe says nothing about the node, the next request probes again
+	send(t, b, errors.NewCommonEdgeX(KindCanceled, "", context.Canceled))
+	if state := b.state(breakerTopic); state != BreakerHalfOpen {
+		t.Fatalf("circuit is %s after a cancelled probe, want half-open", state)
+	}
+	send(t, b, nil)
+	if state := b.state(breakerTopic); state != BreakerClosed {
+		t.Fatalf("circuit is %s after a successful probe, want closed", state)
+	}
+}
+
+func TestNilBreaker(t *testing.T) {
+	var b *breaker
+	send(t, b, errTestTimeout)
+	if state := b.state(breakerTopic); state != BreakerClosed {
+		t.Fatalf("circuit of a nil breaker is %s, want closed", state)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/internal/parallel"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// DefaultBulkParallelism is the number of requests a bulk operation sends at the same time unless configured otherwise
+const DefaultBulkParallelism = 8
+
+// BulkOptions provides the config of a bulk operation
+type BulkOptions struct {
+	// Parallelism bounds the requests sent at the same time, defaults to DefaultBulkParallelism
+	Parallelism int
+	// OnProgress is called once each item completed, one call at a time, e.g. to drive a progress bar. A panic of
+	// OnProgress is recovered and fails the item it reported.
+	OnProgress func(progress BulkProgress)
+}
+
+func NewBulkOptions(parallelism int, onProgress func(progress BulkProgress)) *BulkOptions {
+	return &BulkOptions{
+		Parallelism: parallelism,
+		OnProgress:  onProgress,
+	}
+}
+
+// BulkItemResult is the outcome of a bulk operation for the named item
+type BulkItemResult struct {
+	Name string
+	Err  errors.EdgeX
+}
+
+// BulkProgress reports the item which just completed and the progress of the bulk operation so far
+type BulkProgress struct {
+	Item   BulkItemResult
+	Done   int
+	Failed int
+	Total  int
+}
+
+// BulkResults are the outcomes of a bulk operation, in the order of the items
+type BulkResults []BulkItemResult
+
+// Succeeded returns the names of the items the operation succeeded for
+func (r BulkResults) Succeeded() []string {
+	return r.names(func(result BulkItemResult) bool { return result.Err == nil })
+}
+
+// Failed returns the names of the items the operation failed for
+func (r BulkResults) Failed() []string {
+	return r.names(func(result BulkItemResult) bool { return result.Err != nil })
+}
+
+// Err returns nil if the operation succeeded for every item, or an error listing the failed items which wraps their
+// errors. Its kind is the one of the first failed item.
+func (r BulkResults) Err() errors.EdgeX {
+	var failed []string
+	var errs []error
+	var kind errors.ErrKind
+	for _, result := range r {
+		if result.Err == nil {
+			continue
+		}
+		if len(failed) == 0 {
+			kind = errors.Kind(result.Err)
+		}
+		failed = append(failed, result.Name)
+		errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.NewCommonEdgeX(kind,
+		fmt.Sprintf("bulk operation failed for %d of %d items: %s", len(failed), len(r), strings.Join(failed, ", ")), stdErrors.Join(errs...))
+}
+
+func (r BulkResults) names(match func(result BulkItemResult) bool) []string {
+	var names []string
+	for _, result := range r {
+		if match(result) {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
+
+// AddDevices adds the devices, sending at most Parallelism requests at a time
+func (c *Client) AddDevices(ctx context.Context, devices []dtos.Device, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, devices, func(device dtos.Device) string { return device.Name }, options, c.AddDevice)
+}
+
+// UpdateDevices updates the devices, sending at most Parallelism requests at a time
+func (c *Client) UpdateDevices(ctx context.Context, devices []dtos.Device, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, devices, func(device dtos.Device) string { return device.Name }, options, c.UpdateDevice)
+}
+
+// DeleteDevices deletes the named devices, sending at most Parallelism requests at a time
+func (c *Client) DeleteDevices(ctx context.Context, names []string, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, names, func(name string) string { return name }, options, c.DeleteDeviceByName)
+}
+
+// AddDeviceProfiles adds the profiles, sending at most Parallelism requests at a time
+func (c *Client) AddDeviceProfiles(ctx context.Context, profiles []dtos.DeviceProfile, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, profiles, func(profile dtos.DeviceProfile) string { return profile.Name }, options, c.AddDeviceProfile)
+}
+
+// UpdateDeviceProfiles updates the profiles, sending at most Parallelism requests at a time
+func (c *Client) UpdateDeviceProfiles(ctx context.Context, profiles []dtos.DeviceProfile, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, profiles, func(profile dtos.DeviceProfile) string { return profile.Name }, options, c.UpdateDeviceProfile)
+}
+
+// DeleteDeviceProfiles deletes the named profiles, sending at most Parallelism requests at a time
+func (c *Client) DeleteDeviceProfiles(ctx context.Context, names []string, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, names, func(name string) string { return name }, options, c.DeleteDeviceProfileByName)
+}
+
+// AddSchedules adds the schedules, sending at most Parallelism requests at a time
+func (c *Client) AddSchedules(ctx context.Context, schedules []xrtmodels.Schedule, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, schedules, func(schedule xrtmodels.Schedule) string { return schedule.Name }, options, c.AddSchedule)
+}
+
+// UpdateSchedules updates the schedules, sending at most Parallelism requests at a time
+func (c *Client) UpdateSchedules(ctx context.Context, schedules []xrtmodels.Schedule, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, schedules, func(schedule xrtmodels.Schedule) string { return schedule.Name }, options, c.UpdateSchedule)
+}
+
+// DeleteSchedules deletes the named schedules, sending at most Parallelism requests at a time
+func (c *Client) DeleteSchedules(ctx context.Context, names []string, options *BulkOptions) BulkResults {
+	return RunBulk(ctx, names, func(name string) string { return name }, options, c.DeleteScheduleByName)
+}
+
+// RunBulk runs the operation for every item in order with bounded parallelism, the outcome of each item is named by
+// name. The items not started yet once ctx is done fail with the context error, and a panic of the operation or of
+// OnProgress fails the item. The bulk operations of Client send the items through the methods of the Client itself, so they bypass the
+// EdgeClient decorators wrapping it; decorators offer bulk operations through their own methods with RunBulk, like
+// cache.Client does.
+func RunBulk[T any](ctx context.Context, items []T, name func(item T) string, options *BulkOptions, operation func(ctx context.Context, item T) errors.EdgeX) BulkResults {
+	parallelism := DefaultBulkParallelism
+	var onProgress func(progress BulkProgress)
+	if options != nil {
+		if options.Parallelism > 0 {
+			parallelism = options.Parallelism
+		}
+		onProgress = options.OnProgress
+	}
+
+	results := make(BulkResults, len(items))
+	progress := BulkProgress{Total: len(items)}
+	var mutex sync.Mutex
+	complete := func(i int, err errors.EdgeX) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		results[i] = BulkItemResult{Name: name(items[i]), Err: err}
+		progress.Item = results[i]
+		progress.Done++
+		if err != nil {
+			progress.Failed++
+		}
+		if onProgress != nil {
+			reportProgress(&results[i], &progress, onProgress)
+		}
+	}
+
+	parallel.ForEach(len(items), parallelism,
+		func(i int) bool {
+			if ctx.Err() == nil {
+				return false
+			}
+			complete(i, contextError(ctx))
+			return true
+		},
+		func(i int) {
+			complete(i, operation(ctx, items[i]))
+		},
+		func(i int, recovered any) {
+			complete(i, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("bulk operation panicked for %s: %v", name(items[i]), recovered), nil))
+		})
+	return results
+}
+
+// reportProgress calls onProgress with the progress of the item just completed. A panic of onProgress is recovered
+// here rather than by the caller of complete, which would complete the item a second time or, for the items skipped
+// once ctx is done, crash the caller of RunBulk; the panic fails the item instead.
+func reportProgress(result *BulkItemResult, progress *BulkProgress, onProgress func(progress BulkProgress)) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if result.Err == nil {
+				progress.Failed++
+			}
+			result.Err = errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("bulk progress callback panicked for %s: %v", result.Name, recovered), result.Err)
+		}
+	}()
+	onProgress(*progress)
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+var errBulkItem = stdErrors.New("item failed")
+
+// bulkItems returns the items 0 to n-1
+func bulkItems(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func bulkItemName(item int) string {
+	return strconv.Itoa(item)
+}
+
+// checkProgress checks that every item was reported once, with Done counting up to the total
+func checkProgress(t *testing.T, reported []xrt.BulkProgress, total int) {
+	t.Helper()
+	if len(reported) != total {
+		t.Fatalf("progress reported %d times, want once per item", len(reported))
+	}
+	var names []string
+	for i, progress := range reported {
+		if progress.Done != i+1 || progress.Total != total {
+			t.Fatalf("progress %d reported %+v, want %d of %d done", i, progress, i+1, total)
+		}
+		names = append(names, progress.Item.Name)
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	if len(names) != total {
+		t.Fatalf("progress reported the items %v, want each of them once", names)
+	}
+}
+
+func TestRunBulk(t *testing.T) {
+	var reported []xrt.BulkProgress
+	options := xrt.NewBulkOptions(3, func(progress xrt.BulkProgress) {
+		reported = append(reported, progress)
+	})
+	results := xrt.RunBulk(context.Background(), bulkItems(10), bulkItemName, options, func(ctx context.Context, item int) errors.EdgeX {
+		// the later items complete first
+		time.Sleep(time.Duration(10-item) * time.Millisecond)
+		switch {
+		case item == 5:
+			panic("failed")
+		case item%3 == 0:
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed", errBulkItem)
+		}
+		return nil
+	})
+
+	if len(results) != 10 {
+		t.Fatalf("RunBulk returned %d results, want 10", len(results))
+	}
+	for i, result := range results {
+		if result.Name != bulkItemName(i) {
+			t.Fatalf("result %d is named %s, want the results in the order of the items", i, result.Name)
+		}
+	}
+	if failed := results.Failed(); !slices.Equal(failed, []string{"0", "3", "5", "6", "9"}) {
+		t.Fatalf("RunBulk failed for %v, want 0, 3, 5, 6 and 9", failed)
+	}
+	if err := results[3].Err; !stdErrors.Is(err, errBulkItem) || errors.Kind(err) != errors.KindContractInvalid {
+		t.Fatalf("result of the failed item is %v, want its own error", err)
+	}
+	if err := results[5].Err; errors.Kind(err) != errors.KindServerError {
+		t.Fatalf("result of the panicking item is %v, want a %s error", err, errors.KindServerError)
+	}
+	if err := results.Err(); errors.Kind(err) != errors.KindContractInvalid {
+		t.Fatalf("Err returned %v, want the kind of the first failed item", err)
+	}
+
+	checkProgress(t, reported, 10)
+	if last := reported[len(reported)-1]; last.Failed != 5 {
+		t.Fatalf("last progress reported %d failed items, want 5", last.Failed)
+	}
+}
+
+func TestRunBulkContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var reported []xrt.BulkProgress
+	options := xrt.NewBulkOptions(1, func(progress xrt.BulkProgress) {
+		reported = append(reported, progress)
+	})
+	var ran []int
+	results := xrt.RunBulk(ctx, bulkItems(5), bulkItemName, options, func(ctx context.Context, item int) errors.EdgeX {
+		ran = append(ran, item)
+		if item == 1 {
+			cancel()
+		}
+		return nil
+	})
+
+	if !slices.Equal(ran, []int{0, 1}) {
+		t.Fatalf("operation ran for %v, want the items started before ctx was cancelled", ran)
+	}
+	if succeeded := results.Succeeded(); !slices.Equal(succeeded, []string{"0", "1"}) {
+		t.Fatalf("RunBulk succeeded for %v, want 0 and 1", succeeded)
+	}
+	for _, result := range results[2:] {
+		if !stdErrors.Is(result.Err, context.Canceled) || errors.Kind(result.Err) != xrt.KindCanceled {
+			t.Fatalf("result of %s is %v, want the context error of kind %s", result.Name, result.Err, xrt.KindCanceled)
+		}
+	}
+	checkProgress(t, reported, 5)
+	if last := reported[len(reported)-1]; last.Failed != 3 {
+		t.Fatalf("last progress reported %d failed items, want the 3 not started", last.Failed)
+	}
+}
+
+func TestRunBulkProgressPanic(t *testing.T) {
+	tests := []struct {
+		name      string
+		cancelled bool
+	}{
+		{"run", false},
+		// the items not started are completed on the calling goroutine
+		{"skipped", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+			var reported []xrt.BulkProgress
+			options := xrt.NewBulkOptions(2, func(progress xrt.BulkProgress) {
+				reported = append(reported, progress)
+				if progress.Item.Name == "1" {
+					panic("progress failed")
+				}
+			})
+			results := xrt.RunBulk(ctx, bulkItems(4), bulkItemName, options, func(ctx context.Context, item int) errors.EdgeX {
+				return nil
+			})
+
+			checkProgress(t, reported, 4)
+			err := results[1].Err
+			if errors.Kind(err) != errors.KindServerError || stdErrors.Is(err, context.Canceled) != tt.cancelled {
+				t.Fatalf("result of the item whose progress panicked is %v, want a %s error wrapping its own", err, errors.KindServerError)
+			}
+			for _, i := range []int{0, 2, 3} {
+				if (results[i].Err != nil) != tt.cancelled {
+					t.Fatalf("result of %s is %v, want it unaffected by the panic", results[i].Name, results[i].Err)
+				}
+			}
+			// the panic counts the item as failed in the later reports
+			panicked := false
+			for _, progress := range reported {
+				want := 0
+				if tt.cancelled {
+					want = progress.Done
+				} else if panicked {
+					want = 1
+				}
+				if progress.Failed != want {
+					t.Fatalf("progress reported %+v, want %d failed items", progress, want)
+				}
+				panicked = panicked || progress.Item.Name == "1"
+			}
+		})
+	}
+}
+
+func TestAddDevices(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "bulk/request", ReplyTopic: "bulk/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	client, err := xrt.NewXrtClient(context.Background(), bus, "bulk/request", "bulk/reply", 100*time.Millisecond, logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	xrtClient := client.(*xrt.Client)
+
+	ctx := context.Background()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	var devices []dtos.Device
+	for i := range 6 {
+		device := dtos.Device{Name: fmt.Sprintf("device-%d", i), ProfileName: "profile"}
+		if i == 3 {
+			device.ProfileName = "missing"
+		}
+		devices = append(devices, device)
+	}
+	var reported []xrt.BulkProgress
+	results := xrtClient.AddDevices(ctx, devices, xrt.NewBulkOptions(2, func(progress xrt.BulkProgress) {
+		reported = append(reported, progress)
+	}))
+
+	for i, result := range results {
+		if result.Name != devices[i].Name {
+			t.Fatalf("result %d is named %s, want the results in the order of the devices", i, result.Name)
+		}
+	}
+	if failed := results.Failed(); !slices.Equal(failed, []string{"device-3"}) {
+		t.Fatalf("AddDevices failed for %v, want device-3", failed)
+	}
+	if !stdErrors.Is(results[3].Err, xrt.ErrValidation) {
+		t.Fatalf("result of the device with a missing profile is %v, want ErrValidation", results[3].Err)
+	}
+	if names := node.DeviceNames(); len(names) != 5 || slices.Contains(names, "device-3") {
+		t.Fatalf("node holds the devices %v, want all but device-3", names)
+	}
+	checkProgress(t, reported, 6)
+	if last := reported[len(reported)-1]; last.Failed != 1 {
+		t.Fatalf("last progress reported %d failed items, want 1", last.Failed)
+	}
+}
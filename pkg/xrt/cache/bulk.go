@@ -0,0 +1,57 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package cache
+
+import (
+	"context"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+)
+
+// AddDevices adds the devices like xrt.Client.AddDevices, through the Client to keep the cache up to date
+func (c *Client) AddDevices(ctx context.Context, devices []dtos.Device, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, devices, func(device dtos.Device) string { return device.Name }, options, c.AddDevice)
+}
+
+// UpdateDevices updates the devices like xrt.Client.UpdateDevices, through the Client to keep the cache up to date
+func (c *Client) UpdateDevices(ctx context.Context, devices []dtos.Device, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, devices, func(device dtos.Device) string { return device.Name }, options, c.UpdateDevice)
+}
+
+// DeleteDevices deletes the named devices like xrt.Client.DeleteDevices, through the Client to keep the cache up to date
+func (c *Client) DeleteDevices(ctx context.Context, names []string, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, names, func(name string) string { return name }, options, c.DeleteDeviceByName)
+}
+
+// AddDeviceProfiles adds the profiles like xrt.Client.AddDeviceProfiles, through the Client to keep the cache up to date
+func (c *Client) AddDeviceProfiles(ctx context.Context, profiles []dtos.DeviceProfile, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, profiles, func(profile dtos.DeviceProfile) string { return profile.Name }, options, c.AddDeviceProfile)
+}
+
+// UpdateDeviceProfiles updates the profiles like xrt.Client.UpdateDeviceProfiles, through the Client to keep the cache up to date
+func (c *Client) UpdateDeviceProfiles(ctx context.Context, profiles []dtos.DeviceProfile, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, profiles, func(profile dtos.DeviceProfile) string { return profile.Name }, options, c.UpdateDeviceProfile)
+}
+
+// DeleteDeviceProfiles deletes the named profiles like xrt.Client.DeleteDeviceProfiles, through the Client to keep the cache up to date
+func (c *Client) DeleteDeviceProfiles(ctx context.Context, names []string, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, names, func(name string) string { return name }, options, c.DeleteDeviceProfileByName)
+}
+
+// AddSchedules adds the schedules like xrt.Client.AddSchedules, through the Client to keep the cache up to date
+func (c *Client) AddSchedules(ctx context.Context, schedules []xrtmodels.Schedule, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, schedules, func(schedule xrtmodels.Schedule) string { return schedule.Name }, options, c.AddSchedule)
+}
+
+// UpdateSchedules updates the schedules like xrt.Client.UpdateSchedules, through the Client to keep the cache up to date
+func (c *Client) UpdateSchedules(ctx context.Context, schedules []xrtmodels.Schedule, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, schedules, func(schedule xrtmodels.Schedule) string { return schedule.Name }, options, c.UpdateSchedule)
+}
+
+// DeleteSchedules deletes the named schedules like xrt.Client.DeleteSchedules, through the Client to keep the cache up to date
+func (c *Client) DeleteSchedules(ctx context.Context, names []string, options *xrt.BulkOptions) xrt.BulkResults {
+	return xrt.RunBulk(ctx, names, func(name string) string { return name }, options, c.DeleteScheduleByName)
+}
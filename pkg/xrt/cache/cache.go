@@ -0,0 +1,365 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package cache provides an EdgeClient decorator caching the devices, profiles and schedules read from XRT, so that
+// services reading the same entities over and over don't send a request to XRT each time.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// DefaultTTL is how long the entities are cached unless configured otherwise
+const DefaultTTL = time.Minute
+
+// Options provides the config of a caching Client
+type Options struct {
+	// DeviceTTL, ProfileTTL and ScheduleTTL are how long the entities of each type and the lists of their names are
+	// cached. A negative TTL disables caching for the type, zero defaults to DefaultTTL.
+	DeviceTTL   time.Duration
+	ProfileTTL  time.Duration
+	ScheduleTTL time.Duration
+	// RefreshInterval, if positive, is how often the lists of names are fetched from XRT to drop the entities which
+	// have been deleted by other clients
+	RefreshInterval time.Duration
+	// LoggingClient logs the failed refreshes, which are not logged if nil
+	LoggingClient logger.LoggingClient
+}
+
+func NewOptions(deviceTTL, profileTTL, scheduleTTL, refreshInterval time.Duration, lc logger.LoggingClient) Options {
+	return Options{
+		DeviceTTL:       deviceTTL,
+		ProfileTTL:      profileTTL,
+		ScheduleTTL:     scheduleTTL,
+		RefreshInterval: refreshInterval,
+		LoggingClient:   lc,
+	}
+}
+
+// Client is an EdgeClient caching the devices, profiles and schedules read through the wrapped client. The profiles
+// and schedules added or updated through the Client are cached as written; the devices are dropped from the cache
+// instead, as XRT completes them on write. Changes made by other clients are picked up once the TTL expires, on the
+// periodic refresh of the Options, or when an XRT node reports a change of its status, see WatchStatus. The entities
+// are returned as copies, so callers may modify them. Client is safe for concurrent use.
+type Client struct {
+	interfaces.EdgeClient
+
+	options   Options
+	devices   *store[xrtmodels.DeviceInfo]
+	profiles  *store[dtos.DeviceProfile]
+	schedules *store[xrtmodels.Schedule]
+
+	mutex    sync.Mutex
+	registry *xrt.NodeRegistry
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewClient creates a Client caching the entities read through client, and starts the periodic refresh if configured
+func NewClient(client interfaces.EdgeClient, options Options) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Client{
+		EdgeClient: client,
+		options:    options,
+		devices:    newStore[xrtmodels.DeviceInfo](ttlOrDefault(options.DeviceTTL)),
+		profiles:   newStore[dtos.DeviceProfile](ttlOrDefault(options.ProfileTTL)),
+		schedules:  newStore[xrtmodels.Schedule](ttlOrDefault(options.ScheduleTTL)),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	if options.RefreshInterval > 0 {
+		go c.refreshLoop()
+	} else {
+		close(c.done)
+	}
+	return c
+}
+
+func ttlOrDefault(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		return DefaultTTL
+	}
+	return ttl
+}
+
+// Stats returns the lookup statistics of the cached entities
+func (c *Client) Stats() Stats {
+	return Stats{
+		Devices:   c.devices.stats(),
+		Profiles:  c.profiles.stats(),
+		Schedules: c.schedules.stats(),
+	}
+}
+
+// Flush drops every cached entity
+func (c *Client) Flush() {
+	c.devices.invalidateAll()
+	c.profiles.invalidateAll()
+	c.schedules.invalidateAll()
+}
+
+// InvalidateDevice drops the device from the cache
+func (c *Client) InvalidateDevice(name string) {
+	c.devices.invalidate(name)
+}
+
+// InvalidateDeviceProfile drops the profile from the cache
+func (c *Client) InvalidateDeviceProfile(name string) {
+	c.profiles.invalidate(name)
+}
+
+// InvalidateSchedule drops the schedule from the cache
+func (c *Client) InvalidateSchedule(name string) {
+	c.schedules.invalidate(name)
+}
+
+func (c *Client) AllDevices(ctx context.Context) ([]string, errors.EdgeX) {
+	return cachedList(ctx, c.devices, c.EdgeClient.AllDevices)
+}
+
+func (c *Client) DeviceByName(ctx context.Context, name string) (xrtmodels.DeviceInfo, errors.EdgeX) {
+	return cachedEntity(ctx, c.devices, name, c.EdgeClient.DeviceByName)
+}
+
+func (c *Client) AddDevice(ctx context.Context, device dtos.Device) errors.EdgeX {
+	defer c.devices.invalidate(device.Name)
+	return c.EdgeClient.AddDevice(ctx, device)
+}
+
+func (c *Client) UpdateDevice(ctx context.Context, device dtos.Device) errors.EdgeX {
+	defer c.devices.invalidate(device.Name)
+	return c.EdgeClient.UpdateDevice(ctx, device)
+}
+
+func (c *Client) DeleteDeviceByName(ctx context.Context, name string) errors.EdgeX {
+	err := c.EdgeClient.DeleteDeviceByName(ctx, name)
+	if err != nil {
+		c.devices.invalidate(name)
+		return err
+	}
+	c.devices.remove(name)
+	return nil
+}
+
+func (c *Client) AddDiscoveredDevice(ctx context.Context, device dtos.Device) errors.EdgeX {
+	defer c.devices.invalidate(device.Name)
+	return c.EdgeClient.AddDiscoveredDevice(ctx, device)
+}
+
+func (c *Client) ScanDevice(ctx context.Context, device dtos.Device, options map[string]any, timeout time.Duration) errors.EdgeX {
+	_, err := c.ScanDeviceWithResult(ctx, device, options, timeout)
+	return err
+}
+
+// ScanDeviceWithResult drops the scanned profile from the cache, as the scan may have updated it
+func (c *Client) ScanDeviceWithResult(ctx context.Context, device dtos.Device, options map[string]any, timeout time.Duration) (string, errors.EdgeX) {
+	profileName, err := c.EdgeClient.ScanDeviceWithResult(ctx, device, options, timeout)
+	c.profiles.invalidate(device.ProfileName)
+	if profileName != "" && profileName != device.ProfileName {
+		c.profiles.invalidate(profileName)
+	}
+	return profileName, err
+}
+
+func (c *Client) AllSchedules(ctx context.Context) ([]string, errors.EdgeX) {
+	return cachedList(ctx, c.schedules, c.EdgeClient.AllSchedules)
+}
+
+func (c *Client) AddSchedule(ctx context.Context, schedule xrtmodels.Schedule) errors.EdgeX {
+	return writeThrough(ctx, c.schedules, schedule.Name, schedule, c.EdgeClient.AddSchedule)
+}
+
+func (c *Client) DeleteScheduleByName(ctx context.Context, name string) errors.EdgeX {
+	err := c.EdgeClient.DeleteScheduleByName(ctx, name)
+	if err != nil {
+		c.schedules.invalidate(name)
+		return err
+	}
+	c.schedules.remove(name)
+	return nil
+}
+
+func (c *Client) ScheduleByName(ctx context.Context, name string) (xrtmodels.Schedule, errors.EdgeX) {
+	return cachedEntity(ctx, c.schedules, name, c.EdgeClient.ScheduleByName)
+}
+
+func (c *Client) UpdateSchedule(ctx context.Context, schedule xrtmodels.Schedule) errors.EdgeX {
+	return writeThrough(ctx, c.schedules, schedule.Name, schedule, c.EdgeClient.UpdateSchedule)
+}
+
+func (c *Client) AllDeviceProfiles(ctx context.Context) ([]string, errors.EdgeX) {
+	return cachedList(ctx, c.profiles, c.EdgeClient.AllDeviceProfiles)
+}
+
+func (c *Client) DeviceProfileByName(ctx context.Context, name string) (dtos.DeviceProfile, errors.EdgeX) {
+	return cachedEntity(ctx, c.profiles, name, c.EdgeClient.DeviceProfileByName)
+}
+
+func (c *Client) AddDeviceProfile(ctx context.Context, profile dtos.DeviceProfile) errors.EdgeX {
+	return writeThrough(ctx, c.profiles, profile.Name, profile, c.EdgeClient.AddDeviceProfile)
+}
+
+func (c *Client) UpdateDeviceProfile(ctx context.Context, profile dtos.DeviceProfile) errors.EdgeX {
+	return writeThrough(ctx, c.profiles, profile.Name, profile, c.EdgeClient.UpdateDeviceProfile)
+}
+
+func (c *Client) DeleteDeviceProfileByName(ctx context.Context, name string) errors.EdgeX {
+	err := c.EdgeClient.DeleteDeviceProfileByName(ctx, name)
+	if err != nil {
+		c.profiles.invalidate(name)
+		return err
+	}
+	c.profiles.remove(name)
+	return nil
+}
+
+// WatchStatus flushes the cache whenever the registry reports a change of an XRT node, e.g. its restart, as its
+// entities may have changed. It chains the OnChange func of the registry, so it must be called before the registry is
+// started; the events keep being passed to the previous OnChange once the Client is closed.
+func (c *Client) WatchStatus(registry *xrt.NodeRegistry) errors.EdgeX {
+	if registry == nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "node registry is required to watch the XRT status", nil)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.registry != nil {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, "cache already watches the status of a node registry", nil)
+	}
+
+	previous := registry.OnChange
+	registry.OnChange = func(event xrt.NodeEvent) {
+		if previous != nil {
+			previous(event)
+		}
+		c.handleNodeEvent(event)
+	}
+	c.registry = registry
+	return nil
+}
+
+// Close stops watching the node status and refreshing the cache, and closes the wrapped client
+func (c *Client) Close() errors.EdgeX {
+	c.mutex.Lock()
+	c.registry = nil
+	c.mutex.Unlock()
+
+	c.cancel()
+	<-c.done
+	return c.EdgeClient.Close()
+}
+
+// handleNodeEvent flushes the cache when a node reports its first status or a change of its state, version or
+// components, unless the Client has been closed
+func (c *Client) handleNodeEvent(event xrt.NodeEvent) {
+	c.mutex.Lock()
+	watching := c.registry != nil
+	c.mutex.Unlock()
+	if !watching {
+		return
+	}
+	if c.options.LoggingClient != nil {
+		c.options.LoggingClient.Debugf("flushing the XRT cache after node %s changed to %s", event.Current.ID, event.Current.State)
+	}
+	c.Flush()
+}
+
+// refreshLoop reconciles the cache with the lists of names fetched from XRT every RefreshInterval
+func (c *Client) refreshLoop() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.options.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh()
+		}
+	}
+}
+
+func (c *Client) refresh() {
+	ctx, cancel := context.WithTimeout(c.ctx, c.options.RefreshInterval)
+	defer cancel()
+	c.logRefreshError("devices", reconcile(ctx, c.devices, c.EdgeClient.AllDevices))
+	c.logRefreshError("device profiles", reconcile(ctx, c.profiles, c.EdgeClient.AllDeviceProfiles))
+	c.logRefreshError("schedules", reconcile(ctx, c.schedules, c.EdgeClient.AllSchedules))
+}
+
+func (c *Client) logRefreshError(entities string, err errors.EdgeX) {
+	if err != nil && c.options.LoggingClient != nil {
+		c.options.LoggingClient.Warnf("failed to refresh the cached %s: %v", entities, err)
+	}
+}
+
+// cachedEntity returns a copy of the cached entity, or reads and caches it on a miss. A done ctx bypasses the cache
+// so that the wrapped client reports it.
+func cachedEntity[T any](ctx context.Context, s *store[T], name string, read func(ctx context.Context, name string) (T, errors.EdgeX)) (T, errors.EdgeX) {
+	if !s.enabled() || ctx.Err() != nil {
+		return read(ctx, name)
+	}
+	value, generation, ok := s.get(name)
+	if ok {
+		return deepCopy(value), nil
+	}
+	value, err := read(ctx, name)
+	if err != nil {
+		return value, err
+	}
+	s.put(name, deepCopy(value), generation)
+	return value, nil
+}
+
+// cachedList returns the cached names, or lists and caches them on a miss
+func cachedList[T any](ctx context.Context, s *store[T], list func(ctx context.Context) ([]string, errors.EdgeX)) ([]string, errors.EdgeX) {
+	if !s.enabled() || ctx.Err() != nil {
+		return list(ctx)
+	}
+	names, generation, ok := s.list()
+	if ok {
+		return names, nil
+	}
+	names, err := list(ctx)
+	if err != nil {
+		return names, err
+	}
+	s.putList(names, generation)
+	return names, nil
+}
+
+// writeThrough sends the write and caches a copy of the written entity once XRT accepted it, so the caller may keep
+// modifying its value
+func writeThrough[T any](ctx context.Context, s *store[T], name string, value T, write func(ctx context.Context, value T) errors.EdgeX) errors.EdgeX {
+	if err := write(ctx, value); err != nil {
+		s.invalidate(name)
+		return err
+	}
+	if s.enabled() {
+		s.write(name, deepCopy(value))
+	}
+	return nil
+}
+
+func reconcile[T any](ctx context.Context, s *store[T], list func(ctx context.Context) ([]string, errors.EdgeX)) errors.EdgeX {
+	if !s.enabled() {
+		return nil
+	}
+	generation := s.currentGeneration()
+	names, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	s.reconcile(names, generation)
+	return nil
+}
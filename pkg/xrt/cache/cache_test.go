@@ -0,0 +1,299 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package cache_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/cache"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// newCachedClient returns a caching client of the fake node on bus whose topics start with prefix. The wrapped client,
+// cached.EdgeClient, stands in for other clients changing the entities behind the cache's back.
+func newCachedClient(t *testing.T, bus *xrttest.MessageBus, prefix string, options cache.Options) (*cache.Client, *xrttest.XRT) {
+	t.Helper()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: prefix + "/request", ReplyTopic: prefix + "/reply", StatusTopic: prefix + "/status", NodeID: "node-1"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	t.Cleanup(node.Stop)
+
+	client, err := xrt.NewXrtClient(context.Background(), bus, prefix+"/request", prefix+"/reply", 100*time.Millisecond, logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	cached := cache.NewClient(client, options)
+	t.Cleanup(func() { _ = cached.Close() })
+	return cached, node
+}
+
+// sentRequests counts the requests of the op received by the node
+func sentRequests(node *xrttest.XRT, op string) int {
+	count := 0
+	for _, request := range node.Requests() {
+		if request.Op == op {
+			count++
+		}
+	}
+	return count
+}
+
+func newProfile(name string, description string) dtos.DeviceProfile {
+	return dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: name, Description: description, Labels: []string{"label"}}}
+}
+
+func TestCacheHitsAndMisses(t *testing.T) {
+	cached, node := newCachedClient(t, xrttest.NewMessageBus(), "cachehits", cache.Options{})
+	ctx := context.Background()
+	if err := cached.EdgeClient.AddDeviceProfile(ctx, newProfile("profile", "")); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err := cached.EdgeClient.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+
+	for range 2 {
+		if device, err := cached.DeviceByName(ctx, "device"); err != nil || device.ProfileName != "profile" {
+			t.Fatalf("DeviceByName returned %+v, %v", device, err)
+		}
+		if names, err := cached.AllDevices(ctx); err != nil || !slices.Equal(names, []string{"device"}) {
+			t.Fatalf("AllDevices returned %v, %v", names, err)
+		}
+	}
+	if stats := cached.Stats().Devices; stats.Hits != 2 || stats.Misses != 2 {
+		t.Fatalf("device stats are %+v, want the device and the names missed once and hit once", stats)
+	}
+	if gets, lists := sentRequests(node, xrttest.OpDeviceGet), sentRequests(node, xrttest.OpDeviceList); gets != 1 || lists != 1 {
+		t.Fatalf("node received %d %s and %d %s requests, want 1 of each", gets, xrttest.OpDeviceGet, lists, xrttest.OpDeviceList)
+	}
+
+	// callers may modify the returned entities, whether missed or hit
+	missed, err := cached.DeviceProfileByName(ctx, "profile")
+	if err != nil {
+		t.Fatalf("DeviceProfileByName failed: %v", err)
+	}
+	missed.Labels[0] = "missed"
+	hit, _ := cached.DeviceProfileByName(ctx, "profile")
+	hit.Labels[0] = "hit"
+	if profile, _ := cached.DeviceProfileByName(ctx, "profile"); profile.Labels[0] != "label" {
+		t.Fatalf("cached profile has the labels %v, want them unchanged by the callers", profile.Labels)
+	}
+	if stats := cached.Stats().Profiles; stats.Hits != 2 || stats.Misses != 1 {
+		t.Fatalf("profile stats are %+v, want 1 miss and 2 hits", stats)
+	}
+
+	// a failed read isn't cached
+	for range 2 {
+		if _, err = cached.ScheduleByName(ctx, "missing"); !stdErrors.Is(err, xrt.ErrNotFound) {
+			t.Fatalf("ScheduleByName returned %v, want ErrNotFound", err)
+		}
+	}
+	if reads := sentRequests(node, xrttest.OpScheduleRead); reads != 2 {
+		t.Fatalf("node received %d %s requests for the missing schedule, want 2", reads, xrttest.OpScheduleRead)
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	cached, node := newCachedClient(t, xrttest.NewMessageBus(), "cachedisabled", cache.NewOptions(-1, 0, 0, 0, nil))
+	ctx := context.Background()
+	if err := cached.AddDeviceProfile(ctx, newProfile("profile", "")); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err := cached.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	for range 2 {
+		if _, err := cached.DeviceByName(ctx, "device"); err != nil {
+			t.Fatalf("DeviceByName failed: %v", err)
+		}
+	}
+	if gets := sentRequests(node, xrttest.OpDeviceGet); gets != 2 || cached.Stats().Devices != (cache.EntityStats{}) {
+		t.Fatalf("node received %d %s requests with the stats %+v, want every read sent without caching", gets, xrttest.OpDeviceGet, cached.Stats().Devices)
+	}
+}
+
+func TestDeviceInvalidatedOnWrite(t *testing.T) {
+	cached, node := newCachedClient(t, xrttest.NewMessageBus(), "cachedevice", cache.Options{})
+	ctx := context.Background()
+	for _, name := range []string{"profile-1", "profile-2"} {
+		if err := cached.AddDeviceProfile(ctx, newProfile(name, "")); err != nil {
+			t.Fatalf("AddDeviceProfile failed: %v", err)
+		}
+	}
+	if err := cached.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile-1"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	if _, err := cached.DeviceByName(ctx, "device"); err != nil {
+		t.Fatalf("DeviceByName failed: %v", err)
+	}
+	if names, err := cached.AllDevices(ctx); err != nil || len(names) != 1 {
+		t.Fatalf("AllDevices returned %v, %v", names, err)
+	}
+
+	// XRT completes the written devices, so they are read again rather than cached as written
+	if err := cached.UpdateDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile-2"}); err != nil {
+		t.Fatalf("UpdateDevice failed: %v", err)
+	}
+	if device, err := cached.DeviceByName(ctx, "device"); err != nil || device.ProfileName != "profile-2" {
+		t.Fatalf("DeviceByName returned %+v, %v after the update, want profile-2", device, err)
+	}
+	if gets := sentRequests(node, xrttest.OpDeviceGet); gets != 2 {
+		t.Fatalf("node received %d %s requests, want the updated device read again", gets, xrttest.OpDeviceGet)
+	}
+
+	if err := cached.AddDevice(ctx, dtos.Device{Name: "device-2", ProfileName: "profile-1"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	if names, err := cached.AllDevices(ctx); err != nil || !slices.Equal(names, []string{"device", "device-2"}) {
+		t.Fatalf("AllDevices returned %v, %v after adding device-2", names, err)
+	}
+
+	// a deleted device is removed from the cached names without listing them again
+	if err := cached.DeleteDeviceByName(ctx, "device"); err != nil {
+		t.Fatalf("DeleteDeviceByName failed: %v", err)
+	}
+	lists := sentRequests(node, xrttest.OpDeviceList)
+	if names, err := cached.AllDevices(ctx); err != nil || !slices.Equal(names, []string{"device-2"}) {
+		t.Fatalf("AllDevices returned %v, %v after deleting device", names, err)
+	}
+	if sentRequests(node, xrttest.OpDeviceList) != lists {
+		t.Fatal("deleting a device made the cache list the devices again")
+	}
+	if _, err := cached.DeviceByName(ctx, "device"); !stdErrors.Is(err, xrt.ErrNotFound) {
+		t.Fatalf("DeviceByName returned %v for the deleted device, want ErrNotFound", err)
+	}
+}
+
+func TestWriteThrough(t *testing.T) {
+	cached, node := newCachedClient(t, xrttest.NewMessageBus(), "cachewrite", cache.Options{})
+	ctx := context.Background()
+
+	profile := newProfile("profile", "one")
+	if err := cached.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	profile.Description = "two"
+	if err := cached.UpdateDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("UpdateDeviceProfile failed: %v", err)
+	}
+	if err := cached.AddSchedule(ctx, xrtmodels.Schedule{Name: "schedule"}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+
+	if cachedProfile, err := cached.DeviceProfileByName(ctx, "profile"); err != nil || cachedProfile.Description != "two" {
+		t.Fatalf("DeviceProfileByName returned %+v, %v, want the updated profile", cachedProfile, err)
+	}
+	if schedule, err := cached.ScheduleByName(ctx, "schedule"); err != nil || schedule.Name != "schedule" {
+		t.Fatalf("ScheduleByName returned %+v, %v", schedule, err)
+	}
+	if gets, reads := sentRequests(node, xrttest.OpProfileGet), sentRequests(node, xrttest.OpScheduleRead); gets != 0 || reads != 0 {
+		t.Fatalf("node received %d %s and %d %s requests, want the written entities read from the cache", gets, xrttest.OpProfileGet, reads, xrttest.OpScheduleRead)
+	}
+
+	// a failed write drops the entity, which may be in an unknown state
+	if err := cached.AddDeviceProfile(ctx, newProfile("profile", "three")); !stdErrors.Is(err, xrt.ErrAlreadyExists) {
+		t.Fatalf("AddDeviceProfile returned %v, want ErrAlreadyExists", err)
+	}
+	if cachedProfile, err := cached.DeviceProfileByName(ctx, "profile"); err != nil || cachedProfile.Description != "two" {
+		t.Fatalf("DeviceProfileByName returned %+v, %v after the failed write", cachedProfile, err)
+	}
+	if gets := sentRequests(node, xrttest.OpProfileGet); gets != 1 {
+		t.Fatalf("node received %d %s requests, want the profile read again after the failed write", gets, xrttest.OpProfileGet)
+	}
+}
+
+func TestStatusFlush(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	cached, node := newCachedClient(t, bus, "cachestatus", cache.Options{})
+	ctx := context.Background()
+
+	events := make(chan xrt.NodeEvent, 16)
+	registry := xrt.NewNodeRegistry(time.Hour, nil)
+	registry.OnChange = func(event xrt.NodeEvent) { events <- event }
+	if err := cached.WatchStatus(registry); err != nil {
+		t.Fatalf("WatchStatus failed: %v", err)
+	}
+	if err := cached.WatchStatus(registry); errors.Kind(err) != errors.KindStatusConflict {
+		t.Fatalf("second WatchStatus returned %v, want a %s error", err, errors.KindStatusConflict)
+	}
+	if err := registry.Start(bus, "cachestatus/status", logger.NewMockClient()); err != nil {
+		t.Fatalf("failed to start node registry: %v", err)
+	}
+	defer registry.Stop()
+
+	if err := cached.AddDeviceProfile(ctx, newProfile("profile", "one")); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	// another client updates the profile
+	if err := cached.EdgeClient.UpdateDeviceProfile(ctx, newProfile("profile", "two")); err != nil {
+		t.Fatalf("UpdateDeviceProfile failed: %v", err)
+	}
+	if profile, _ := cached.DeviceProfileByName(ctx, "profile"); profile.Description != "one" {
+		t.Fatalf("DeviceProfileByName returned %+v before the status change, want the cached profile", profile)
+	}
+
+	if err := node.PublishStatus("online"); err != nil {
+		t.Fatalf("PublishStatus failed: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event.Current.ID != "node-1" {
+			t.Fatalf("previous OnChange was called with %+v, want the status of node-1", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("previous OnChange wasn't called by the chained one")
+	}
+	// the chained OnChange flushes after calling the previous one
+	deadline := time.Now().Add(time.Second)
+	for cached.Stats().Profiles.Invalidations == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if profile, err := cached.DeviceProfileByName(ctx, "profile"); err != nil || profile.Description != "two" {
+		t.Fatalf("DeviceProfileByName returned %+v, %v after the status change, want the updated profile", profile, err)
+	}
+}
+
+func TestRefreshLoop(t *testing.T) {
+	cached, _ := newCachedClient(t, xrttest.NewMessageBus(), "cacherefresh", cache.NewOptions(0, 0, 0, 20*time.Millisecond, logger.NewMockClient()))
+	ctx := context.Background()
+	if err := cached.AddDeviceProfile(ctx, newProfile("profile", "")); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	for _, name := range []string{"device-1", "device-2"} {
+		if err := cached.AddDevice(ctx, dtos.Device{Name: name, ProfileName: "profile"}); err != nil {
+			t.Fatalf("AddDevice failed: %v", err)
+		}
+		if _, err := cached.DeviceByName(ctx, name); err != nil {
+			t.Fatalf("DeviceByName failed: %v", err)
+		}
+	}
+
+	// another client deletes device-1, which the refresh drops from the cache
+	if err := cached.EdgeClient.DeleteDeviceByName(ctx, "device-1"); err != nil {
+		t.Fatalf("DeleteDeviceByName failed: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for cached.Stats().Devices.Invalidations == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if stats := cached.Stats().Devices; stats.Invalidations != 1 {
+		t.Fatalf("device stats are %+v after the refresh, want device-1 dropped", stats)
+	}
+	if _, err := cached.DeviceByName(ctx, "device-1"); !stdErrors.Is(err, xrt.ErrNotFound) {
+		t.Fatalf("DeviceByName returned %v for the deleted device, want ErrNotFound", err)
+	}
+	if names, err := cached.AllDevices(ctx); err != nil || !slices.Equal(names, []string{"device-2"}) {
+		t.Fatalf("AllDevices returned %v, %v after the refresh, want device-2", names, err)
+	}
+}
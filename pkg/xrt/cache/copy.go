@@ -0,0 +1,66 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package cache
+
+import "reflect"
+
+// deepCopy returns a copy of the value which shares no maps, slices or pointers with it. Unexported struct fields are
+// copied shallowly.
+func deepCopy[T any](value T) T {
+	copied, _ := copyValue(reflect.ValueOf(&value).Elem()).Interface().(T)
+	return copied
+}
+
+func copyValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(copyValue(value.Elem()))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(copyValue(value.Elem()))
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			copied.Index(i).Set(copyValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := range value.Len() {
+			copied.Index(i).Set(copyValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := range value.NumField() {
+			if field := copied.Field(i); field.CanSet() {
+				field.Set(copyValue(value.Field(i)))
+			}
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package cache
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EntityStats counts the lookups of an entity type
+type EntityStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// Invalidations counts the entries dropped before their TTL expired, e.g. after a mutation or an external change
+	Invalidations uint64 `json:"invalidations"`
+}
+
+// Stats are the lookup statistics of a caching Client by entity type
+type Stats struct {
+	Devices   EntityStats `json:"devices"`
+	Profiles  EntityStats `json:"profiles"`
+	Schedules EntityStats `json:"schedules"`
+}
+
+type entry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// store caches the entities of one type by name, along with the list of their names. Every write and invalidation
+// bumps the generation, so a fetch which started before can't store a stale value.
+type store[T any] struct {
+	ttl time.Duration
+
+	mutex      sync.Mutex
+	entries    map[string]entry[T]
+	names      *entry[[]string]
+	generation uint64
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func newStore[T any](ttl time.Duration) *store[T] {
+	return &store[T]{ttl: ttl, entries: make(map[string]entry[T])}
+}
+
+func (s *store[T]) enabled() bool {
+	return s.ttl > 0
+}
+
+// get returns the cached entity and the generation to pass to put on a miss
+func (s *store[T]) get(name string) (T, uint64, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	cached, ok := s.entries[name]
+	if ok && time.Now().Before(cached.expires) {
+		s.hits.Add(1)
+		return cached.value, s.generation, true
+	}
+	s.misses.Add(1)
+	var zero T
+	return zero, s.generation, false
+}
+
+// put caches the fetched entity unless the store changed since generation
+func (s *store[T]) put(name string, value T, generation uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.generation == generation {
+		s.entries[name] = entry[T]{value: value, expires: time.Now().Add(s.ttl)}
+	}
+}
+
+// list returns the cached names and the generation to pass to putList on a miss
+func (s *store[T]) list() ([]string, uint64, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.names != nil && time.Now().Before(s.names.expires) {
+		s.hits.Add(1)
+		return slices.Clone(s.names.value), s.generation, true
+	}
+	s.misses.Add(1)
+	return nil, s.generation, false
+}
+
+// putList caches the fetched names unless the store changed since generation
+func (s *store[T]) putList(names []string, generation uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.generation == generation {
+		s.names = &entry[[]string]{value: slices.Clone(names), expires: time.Now().Add(s.ttl)}
+	}
+}
+
+// write caches the entity written by the client and adds it to the cached names
+func (s *store[T]) write(name string, value T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	s.entries[name] = entry[T]{value: value, expires: time.Now().Add(s.ttl)}
+	if s.names != nil && !slices.Contains(s.names.value, name) {
+		s.names.value = append(s.names.value, name)
+	}
+}
+
+// remove drops the entity deleted by the client and removes it from the cached names
+func (s *store[T]) remove(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	s.drop(name)
+	if s.names != nil {
+		s.names.value = slices.DeleteFunc(s.names.value, func(cached string) bool { return cached == name })
+	}
+}
+
+// invalidate drops the entity and the cached names
+func (s *store[T]) invalidate(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	s.drop(name)
+	s.names = nil
+}
+
+// invalidateAll drops every entity and the cached names
+func (s *store[T]) invalidateAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	s.invalidations.Add(uint64(len(s.entries)))
+	clear(s.entries)
+	s.names = nil
+}
+
+// currentGeneration returns the generation to pass to reconcile
+func (s *store[T]) currentGeneration() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.generation
+}
+
+// reconcile replaces the cached names with the current ones and drops the entities which no longer exist, unless the
+// store changed since generation as the names may miss the entities written since
+func (s *store[T]) reconcile(names []string, generation uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.generation != generation {
+		return
+	}
+	s.generation++
+	for name := range s.entries {
+		if !slices.Contains(names, name) {
+			s.drop(name)
+		}
+	}
+	s.names = &entry[[]string]{value: slices.Clone(names), expires: time.Now().Add(s.ttl)}
+}
+
+// drop removes the entity, the caller must hold the mutex
+func (s *store[T]) drop(name string) {
+	if _, ok := s.entries[name]; ok {
+		delete(s.entries, name)
+		s.invalidations.Add(1)
+	}
+}
+
+func (s *store[T]) stats() EntityStats {
+	return EntityStats{Hits: s.hits.Load(), Misses: s.misses.Load(), Invalidations: s.invalidations.Load()}
+}
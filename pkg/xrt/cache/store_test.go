@@ -0,0 +1,47 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+func TestReconcileDiscardedAfterWrite(t *testing.T) {
+	s := newStore[string](time.Minute)
+	s.write("stale", "value")
+	// the entity is written while the names are listed, so they miss it
+	err := reconcile(context.Background(), s, func(context.Context) ([]string, errors.EdgeX) {
+		s.write("written", "value")
+		return []string{}, nil
+	})
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if _, _, ok := s.get("written"); !ok {
+		t.Fatal("reconcile dropped the entity written while listing the names")
+	}
+}
+
+func TestWriteThroughCachesCopy(t *testing.T) {
+	s := newStore[dtos.DeviceProfile](time.Minute)
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile", Labels: []string{"a"}},
+		DeviceResources:        []dtos.DeviceResource{{Name: "resource", Attributes: map[string]any{"register": 1}}},
+	}
+	write := func(context.Context, dtos.DeviceProfile) errors.EdgeX { return nil }
+	if err := writeThrough(context.Background(), s, profile.Name, profile, write); err != nil {
+		t.Fatalf("writeThrough failed: %v", err)
+	}
+
+	profile.Labels[0] = "b"
+	profile.DeviceResources[0].Attributes["register"] = 2
+	cached, _, _ := s.get("profile")
+	if cached.Labels[0] != "a" || cached.DeviceResources[0].Attributes["register"] != 1 {
+		t.Fatalf("cached profile %+v changed along with the written one", cached)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// Version is the version of an XRT node
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+var (
+	// XRT21 is Edge XRT 2.1
+	XRT21 = Version{Major: 2, Minor: 1}
+	// XRT22 is Edge XRT 2.2, the version the client targets
+	XRT22 = Version{Major: 2, Minor: 2}
+)
+
+// ParseVersion parses versions like 2.2, v2.1.3 or 2.2.0-rc1
+func ParseVersion(s string) (Version, errors.EdgeX) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(trimmed, "-+ "); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	parts := strings.Split(trimmed, ".")
+	if len(parts) < 2 {
+		return Version{}, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid XRT version %q", s), nil)
+	}
+	var numbers [3]int
+	for i := 0; i < len(parts) && i < len(numbers); i++ {
+		number, err := strconv.Atoi(parts[i])
+		if err != nil || number < 0 {
+			return Version{}, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid XRT version %q", s), err)
+		}
+		numbers[i] = number
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is older than, equal to or newer than other
+func (v Version) Compare(other Version) int {
+	if v.Major != other.Major {
+		return cmp.Compare(v.Major, other.Major)
+	}
+	if v.Minor != other.Minor {
+		return cmp.Compare(v.Minor, other.Minor)
+	}
+	return cmp.Compare(v.Patch, other.Patch)
+}
+
+// Before reports whether v is older than other
+func (v Version) Before(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// opsSince lists the operations which XRT doesn't support before the given version
+var opsSince = map[string]Version{
+	OpDeviceAddDiscovered: XRT22,
+}
+
+// scanProfileSince is the first version returning the scanned profile in result.profile of device:scan
+var scanProfileSince = XRT22
+
+// Capabilities describes what an XRT node, or all the nodes addressed by a client, can handle
+type Capabilities struct {
+	// Node is the node the capabilities apply to, empty for the capabilities common to all known nodes
+	Node    string
+	Version Version
+	// Detected reports whether the version was reported by the nodes rather than assumed, see CapabilityOptions
+	Detected bool
+	// Components are the components hosted by the node, if reported
+	Components []NodeComponent
+	// ScanReturnsProfile reports whether device:scan returns the scanned profile in result.profile
+	ScanReturnsProfile bool
+}
+
+// CapabilitiesOf returns the capabilities of an XRT node of the given version
+func CapabilitiesOf(version Version) Capabilities {
+	return Capabilities{
+		Version:            version,
+		ScanReturnsProfile: !version.Before(scanProfileSince),
+	}
+}
+
+// Supports reports whether the op can be sent to the node
+func (c Capabilities) Supports(op string) bool {
+	since, ok := opsSince[op]
+	return !ok || !c.Version.Before(since)
+}
+
+// CapabilityOptions enables the version negotiation with XRT: requests the nodes can't handle are rejected with an
+// error matching ErrUnsupported, and the requests and replies of ops whose format changed are adapted to the version
+// of the nodes. The versions are taken from the status of the nodes and from DetectCapabilities.
+type CapabilityOptions struct {
+	// NodeRegistry provides the versions reported in the status of the nodes
+	NodeRegistry *NodeRegistry
+	// AssumedVersion is the version of nodes which haven't reported one, defaults to XRT22
+	AssumedVersion *Version
+}
+
+func NewCapabilityOptions(nodeRegistry *NodeRegistry, assumedVersion *Version) *CapabilityOptions {
+	return &CapabilityOptions{
+		NodeRegistry:   nodeRegistry,
+		AssumedVersion: assumedVersion,
+	}
+}
+
+// detectedNodes keeps the nodes found by DetectCapabilities, shared by a client and its node views
+type detectedNodes struct {
+	mutex sync.RWMutex
+	nodes map[string]Capabilities
+}
+
+// componentDiscoveryReply captures the node, version and components of a component:discover reply
+type componentDiscoveryReply struct {
+	Result struct {
+		Node       string          `json:"node"`
+		Version    string          `json:"version"`
+		Components []NodeComponent `json:"components"`
+	} `json:"result"`
+}
+
+// DetectCapabilities discovers the XRT nodes replying within subscribeTimeout and returns their capabilities. The
+// versions they report are used for the version negotiation from now on, as are those of the NodeRegistry of the
+// CapabilityOptions.
+func (c *Client) DetectCapabilities(ctx context.Context, subscribeTimeout time.Duration) ([]Capabilities, errors.EdgeX) {
+	request := xrtmodels.NewComponentDiscoverRequest(clientName, "")
+	replies, err := sendXrtRequestWithSubTimeout[componentDiscoveryReply](ctx, c, OpComponentDiscover, c.requestTopic, request.RequestId, request, subscribeTimeout, nil)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to detect the XRT capabilities", err)
+	}
+
+	var detected []Capabilities
+	for reply := range replies {
+		capabilities := CapabilitiesOf(c.assumedVersion())
+		if version, parseErr := ParseVersion(reply.Result.Version); parseErr == nil {
+			capabilities = CapabilitiesOf(version)
+			capabilities.Detected = true
+		}
+		capabilities.Node = reply.Result.Node
+		capabilities.Components = reply.Result.Components
+		detected = append(detected, capabilities)
+	}
+	if ctx.Err() != nil {
+		ctxErr := contextError(ctx)
+		return nil, errors.NewCommonEdgeX(errors.Kind(ctxErr), "failed to detect the XRT capabilities", ctxErr)
+	}
+
+	c.detected.mutex.Lock()
+	for _, capabilities := range detected {
+		if capabilities.Node != "" && capabilities.Detected {
+			c.detected.nodes[capabilities.Node] = capabilities
+		}
+	}
+	c.detected.mutex.Unlock()
+	return detected, nil
+}
+
+// Capabilities returns the capabilities of the addressed node for node views, see ForNode, or otherwise those common
+// to all known nodes, i.e. of the oldest version. Nodes of unknown version are assumed to run the AssumedVersion of
+// the CapabilityOptions.
+func (c *Client) Capabilities() Capabilities {
+	known := c.knownNodes()
+	if c.nodeID != "" {
+		if capabilities, ok := known[c.nodeID]; ok {
+			return capabilities
+		}
+		capabilities := CapabilitiesOf(c.assumedVersion())
+		capabilities.Node = c.nodeID
+		return capabilities
+	}
+
+	if len(known) == 0 {
+		return CapabilitiesOf(c.assumedVersion())
+	}
+	oldest := slices.MinFunc(slices.Collect(maps.Values(known)), func(a, b Capabilities) int {
+		return a.Version.Compare(b.Version)
+	})
+	common := CapabilitiesOf(oldest.Version)
+	common.Detected = true
+	return common
+}
+
+// knownNodes returns the capabilities of the nodes whose version has been reported, by node id. The status of the
+// NodeRegistry takes precedence over DetectCapabilities; offline nodes are left out.
+func (c *Client) knownNodes() map[string]Capabilities {
+	c.detected.mutex.RLock()
+	known := make(map[string]Capabilities, len(c.detected.nodes))
+	maps.Copy(known, c.detected.nodes)
+	c.detected.mutex.RUnlock()
+
+	if c.clientOptions == nil || c.clientOptions.Capabilities == nil || c.clientOptions.Capabilities.NodeRegistry == nil {
+		return known
+	}
+	for _, node := range c.clientOptions.Capabilities.NodeRegistry.Nodes() {
+		if node.State == NodeOffline {
+			delete(known, node.ID)
+			continue
+		}
+		version, err := ParseVersion(node.Version)
+		if err != nil {
+			continue
+		}
+		capabilities := CapabilitiesOf(version)
+		capabilities.Node = node.ID
+		capabilities.Detected = true
+		capabilities.Components = node.Components
+		known[node.ID] = capabilities
+	}
+	return known
+}
+
+func (c *Client) assumedVersion() Version {
+	if c.clientOptions != nil && c.clientOptions.Capabilities != nil && c.clientOptions.Capabilities.AssumedVersion != nil {
+		return *c.clientOptions.Capabilities.AssumedVersion
+	}
+	return XRT22
+}
+
+func (c *Client) negotiating() bool {
+	return c.clientOptions != nil && c.clientOptions.Capabilities != nil
+}
+
+// checkSupported rejects the op if the addressed nodes can't handle it
+func (c *Client) checkSupported(op string) errors.EdgeX {
+	if !c.negotiating() {
+		return nil
+	}
+	capabilities := c.Capabilities()
+	if capabilities.Supports(op) {
+		return nil
+	}
+	return errors.NewCommonEdgeX(errors.KindNotImplemented,
+		fmt.Sprintf("XRT request %s is unsupported on XRT %d.%d", op, capabilities.Version.Major, capabilities.Version.Minor), ErrUnsupported)
+}
+
+// versionAdapter converts the request or the replies of an op for the XRT versions before the given one, whose
+// format differs from the one the client uses
+type versionAdapter struct {
+	op     string
+	before Version
+	adapt  func(c *Client, ctx context.Context, call *Call, next Invoker) ([][]byte, errors.EdgeX)
+}
+
+var versionAdapters = []versionAdapter{
+	{op: OpDeviceScan, before: scanProfileSince, adapt: (*Client).adaptScanProfile},
+}
+
+// adapters returns the interceptors adapting the call to the version of the addressed nodes
+func (c *Client) adapters(op string) []Interceptor {
+	if !c.negotiating() {
+		return nil
+	}
+	var interceptors []Interceptor
+	var version *Version
+	for _, adapter := range versionAdapters {
+		if adapter.op != op {
+			continue
+		}
+		if version == nil {
+			v := c.Capabilities().Version
+			version = &v
+		}
+		if version.Before(adapter.before) {
+			adapt := adapter.adapt
+			interceptors = append(interceptors, func(ctx context.Context, call *Call, next Invoker) ([][]byte, errors.EdgeX) {
+				return adapt(c, ctx, call, next)
+			})
+		}
+	}
+	return interceptors
+}
+
+// adaptScanProfile fills in result.profile of device:scan replies of XRT 2.1, which updates the requested profile
+// without returning its name
+func (c *Client) adaptScanProfile(ctx context.Context, call *Call, next Invoker) ([][]byte, errors.EdgeX) {
+	replies, err := next(ctx, call)
+	if err != nil {
+		return replies, err
+	}
+
+	var request map[string]any
+	if decodeErr := c.codec.Unmarshal(call.Request, &request); decodeErr != nil {
+		return replies, nil
+	}
+	profileName := targetsOf(request)["profile"]
+	if profileName == "" {
+		return replies, nil
+	}
+
+	adapted := make([][]byte, 0, len(replies))
+	for _, reply := range replies {
+		var fields map[string]any
+		if decodeErr := c.codec.Unmarshal(reply, &fields); decodeErr != nil || resultStatus(c.codec, reply) != StatusOK {
+			adapted = append(adapted, reply)
+			continue
+		}
+		result, ok := fields["result"].(map[string]any)
+		if !ok || result["profile"] != nil {
+			adapted = append(adapted, reply)
+			continue
+		}
+		result["profile"] = profileName
+		encoded, encodeErr := c.codec.Marshal(fields)
+		if encodeErr != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to %s encode the adapted reply", c.codec.Name()), encodeErr)
+		}
+		adapted = append(adapted, encoded)
+	}
+	return adapted, nil
+}
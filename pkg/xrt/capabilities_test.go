@@ -0,0 +1,215 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    xrt.Version
+		invalid bool
+	}{
+		{"2.2", xrt.XRT22, false},
+		{"v2.1.3", xrt.Version{Major: 2, Minor: 1, Patch: 3}, false},
+		{" 2.2.0-rc1", xrt.XRT22, false},
+		{"2.3+build.7", xrt.Version{Major: 2, Minor: 3}, false},
+		{"2.1 (edge)", xrt.XRT21, false},
+		{"2.2.1.9", xrt.Version{Major: 2, Minor: 2, Patch: 1}, false},
+		{"", xrt.Version{}, true},
+		{"2", xrt.Version{}, true},
+		{"v", xrt.Version{}, true},
+		{"2.x", xrt.Version{}, true},
+		{"2.-1", xrt.Version{}, true},
+		{"-rc1", xrt.Version{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			version, err := xrt.ParseVersion(tt.version)
+			if tt.invalid {
+				if err == nil || errors.Kind(err) != errors.KindContractInvalid {
+					t.Fatalf("ParseVersion returned %v, %v, want a %s error", version, err, errors.KindContractInvalid)
+				}
+				return
+			}
+			if err != nil || version != tt.want {
+				t.Fatalf("ParseVersion returned %v, %v, want %v", version, err, tt.want)
+			}
+		})
+	}
+}
+
+// newNegotiatingClient returns a client negotiating the versions of the nodes known to the registry, addressing the
+// node views on prefix/request/<node id>
+func newNegotiatingClient(t *testing.T, bus *xrttest.MessageBus, prefix string, registry *xrt.NodeRegistry, assumedVersion *xrt.Version) *xrt.Client {
+	t.Helper()
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Nodes = xrt.NewNodeOptions(prefix+"/request/"+xrt.NodeIDPlaceholder, "")
+	options.Capabilities = xrt.NewCapabilityOptions(registry, assumedVersion)
+	client, err := xrt.NewXrtClient(context.Background(), bus, prefix+"/request", prefix+"/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client.(*xrt.Client)
+}
+
+func TestCapabilities(t *testing.T) {
+	registry := xrt.NewNodeRegistry(time.Hour, nil)
+	client := newNegotiatingClient(t, xrttest.NewMessageBus(), "capabilities", registry, &xrt.XRT21)
+
+	// without known nodes the assumed version applies
+	if capabilities := client.Capabilities(); capabilities.Version != xrt.XRT21 || capabilities.Detected {
+		t.Fatalf("Capabilities returned %+v without known nodes, want the assumed version", capabilities)
+	}
+
+	statuses := []xrt.NodeStatus{
+		{Node: "node-1", Status: "online", Version: "2.3"},
+		{Node: "node-2", Status: "online", Version: "v2.2.1"},
+		{Node: "node-3", Status: "offline", Version: "2.0"},
+		{Node: "node-4", Status: "online"},
+	}
+	for _, status := range statuses {
+		if err := registry.Update(status); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+	tests := []struct {
+		name     string
+		node     string
+		version  xrt.Version
+		detected bool
+	}{
+		{"oldest common version", "", xrt.Version{Major: 2, Minor: 2, Patch: 1}, true},
+		{"node view", "node-1", xrt.Version{Major: 2, Minor: 3}, true},
+		{"offline node view", "node-3", xrt.XRT21, false},
+		{"node view without version", "node-4", xrt.XRT21, false},
+		{"unknown node view", "node-5", xrt.XRT21, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := client
+			if tt.node != "" {
+				var err errors.EdgeX
+				if view, err = client.ForNode(tt.node); err != nil {
+					t.Fatalf("ForNode failed: %v", err)
+				}
+			}
+			capabilities := view.Capabilities()
+			if capabilities.Node != tt.node || capabilities.Version != tt.version || capabilities.Detected != tt.detected {
+				t.Fatalf("Capabilities returned %+v, want node %q at %v, detected %v", capabilities, tt.node, tt.version, tt.detected)
+			}
+		})
+	}
+}
+
+func TestUnsupportedOp(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "unsupported/request", ReplyTopic: "unsupported/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	registry := xrt.NewNodeRegistry(time.Hour, nil)
+	if err := registry.Update(xrt.NodeStatus{Node: "node-1", Status: "online", Version: "2.1.4"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	client := newNegotiatingClient(t, bus, "unsupported", registry, nil)
+
+	err := client.AddDiscoveredDevice(context.Background(), dtos.Device{Name: "device"})
+	if !stdErrors.Is(err, xrt.ErrUnsupported) || errors.Kind(err) != errors.KindNotImplemented {
+		t.Fatalf("AddDiscoveredDevice returned %v on XRT 2.1, want ErrUnsupported of kind %s", err, errors.KindNotImplemented)
+	}
+	if want := "XRT request " + xrt.OpDeviceAddDiscovered + " is unsupported on XRT 2.1"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("AddDiscoveredDevice returned %q, want it to contain %q", err.Error(), want)
+	}
+	if requests := node.Requests(); len(requests) != 0 {
+		t.Fatalf("node received %+v, want the unsupported request rejected by the client", requests)
+	}
+}
+
+func TestScanProfileAdapter(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     xrt.Version
+		profileName string
+		want        string
+	}{
+		{"2.2 returns the profile", xrt.XRT22, "", "device-profile"},
+		{"2.2 returns the requested profile", xrt.XRT22, "profile", "profile"},
+		{"2.1 requested profile filled in", xrt.XRT21, "profile", "profile"},
+		{"2.1 generated profile unknown", xrt.XRT21, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := "scanprofile/" + tt.version.String() + "/" + tt.profileName
+			bus := xrttest.NewMessageBus()
+			node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: prefix + "/request", ReplyTopic: prefix + "/reply", Version: tt.version.String()})
+			if err := node.Start(); err != nil {
+				t.Fatalf("failed to start fake XRT node: %v", err)
+			}
+			defer node.Stop()
+			client := newNegotiatingClient(t, bus, prefix, nil, &tt.version)
+
+			device := dtos.Device{Name: "device", ProfileName: tt.profileName}
+			profileName, err := client.ScanDeviceWithResult(context.Background(), device, nil, time.Second)
+			if err != nil || profileName != tt.want {
+				t.Fatalf("ScanDeviceWithResult returned %q, %v, want %q", profileName, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{NodeID: "node-1", RequestTopic: "detect/request", ReplyTopic: "detect/reply", Version: "2.1"})
+	node.AddComponent(xrttest.Component{Name: "modbus-1", Category: "south"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	client := newNegotiatingClient(t, bus, "detect", nil, nil)
+	ctx := context.Background()
+
+	if capabilities := client.Capabilities(); capabilities.Version != xrt.XRT22 || capabilities.Detected {
+		t.Fatalf("Capabilities returned %+v before the detection, want XRT 2.2 assumed", capabilities)
+	}
+	detected, err := client.DetectCapabilities(ctx, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("DetectCapabilities failed: %v", err)
+	}
+	if len(detected) != 1 || detected[0].Node != "node-1" || detected[0].Version != xrt.XRT21 || !detected[0].Detected ||
+		detected[0].ScanReturnsProfile || len(detected[0].Components) != 1 {
+		t.Fatalf("DetectCapabilities returned %+v, want node-1 at XRT 2.1 with its component", detected)
+	}
+	if capabilities := client.Capabilities(); capabilities.Version != xrt.XRT21 || !capabilities.Detected {
+		t.Fatalf("Capabilities returned %+v after the detection, want XRT 2.1 detected", capabilities)
+	}
+
+	// the detected version adapts the scan and rejects the ops 2.1 lacks
+	profileName, err := client.ScanDeviceWithResult(ctx, dtos.Device{Name: "device", ProfileName: "profile"}, nil, time.Second)
+	if err != nil || profileName != "profile" {
+		t.Fatalf("ScanDeviceWithResult returned %q, %v, want the requested profile", profileName, err)
+	}
+	if err = client.AddDiscoveredDevice(ctx, dtos.Device{Name: "device"}); !stdErrors.Is(err, xrt.ErrUnsupported) {
+		t.Fatalf("AddDiscoveredDevice returned %v, want ErrUnsupported", err)
+	}
+	for _, request := range node.Requests() {
+		if request.Op == xrttest.OpDeviceAddDiscovered {
+			t.Fatal("node received the request unsupported by its version")
+		}
+	}
+}
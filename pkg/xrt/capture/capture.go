@@ -0,0 +1,96 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package capture records the message bus traffic of xrt.Client into a JSONL capture file and replays a capture,
+// so field issues can be reproduced and turned into regression tests.
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Direction tells whether an Entry was published or received by the recorded client
+type Direction string
+
+const (
+	DirectionPublish Direction = "publish"
+	DirectionReceive Direction = "receive"
+)
+
+// maxLineSize is the largest capture line ReadCapture accepts
+const maxLineSize = 16 << 20
+
+// Entry is a message published or received by the recorded client
+type Entry struct {
+	Time      time.Time
+	Direction Direction
+	Topic     string
+	Payload   []byte
+}
+
+// entryJSON is the encoding of an Entry in the capture file. JSON payloads are embedded as they are to keep captures
+// readable, other payloads, such as CBOR, are base64 encoded.
+type entryJSON struct {
+	Time          time.Time       `json:"time"`
+	Direction     Direction       `json:"direction"`
+	Topic         string          `json:"topic"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	PayloadBase64 []byte          `json:"payload_base64,omitempty"`
+}
+
+func (e Entry) MarshalJSON() ([]byte, error) {
+	encoded := entryJSON{Time: e.Time, Direction: e.Direction, Topic: e.Topic}
+	if json.Valid(e.Payload) {
+		encoded.Payload = e.Payload
+	} else {
+		encoded.PayloadBase64 = e.Payload
+	}
+	return json.Marshal(encoded)
+}
+
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var encoded entryJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	*e = Entry{Time: encoded.Time, Direction: encoded.Direction, Topic: encoded.Topic, Payload: encoded.PayloadBase64}
+	if encoded.Payload != nil {
+		e.Payload = []byte(encoded.Payload)
+	}
+	return nil
+}
+
+// ReadCapture reads the entries of a capture written by a Recorder
+func ReadCapture(r io.Reader) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	var entries []Entry
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode capture line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read capture: %w", err)
+	}
+	return entries, nil
+}
+
+// LoadCapture reads the entries of the capture file at path
+func LoadCapture(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture file %s: %w", path, err)
+	}
+	defer file.Close()
+	return ReadCapture(file)
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	stdErrors "errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/internal/membus"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// answer replies to the device:list and device:get requests published on requestTopic, encoded with payloadCodec
+func answer(t *testing.T, bus *membus.MessageBus, requestTopic string, replyTopic string, payloadCodec codec.Codec) {
+	t.Helper()
+	messages := make(chan types.MessageEnvelope)
+	if err := bus.SubscribeBinaryData([]types.TopicChannel{{Topic: requestTopic, Messages: messages}}, nil); err != nil {
+		t.Fatalf("failed to subscribe to %s: %v", requestTopic, err)
+	}
+	t.Cleanup(func() { _ = bus.Unsubscribe(requestTopic) })
+	go func() {
+		for message := range messages {
+			var request struct {
+				RequestId string `json:"request_id"`
+				Op        string `json:"op"`
+				Device    string `json:"device"`
+			}
+			_ = payloadCodec.Unmarshal(message.Payload.([]byte), &request)
+			result := map[string]any{"status": xrt.StatusOK, "devices": []string{"device"}}
+			if request.Op == xrt.OpDeviceGet {
+				result = map[string]any{"status": xrt.StatusOK, "device": map[string]any{"name": request.Device, "profileName": "profile"}}
+			}
+			reply, _ := payloadCodec.Marshal(map[string]any{"request_id": request.RequestId, "result": result})
+			_ = bus.PublishBinaryData(reply, replyTopic)
+		}
+	}()
+}
+
+// newCaptureClient creates a client on bus whose topics start with prefix
+func newCaptureClient(t *testing.T, bus messaging.MessageClient, prefix string, payloadCodec codec.Codec) *xrt.Client {
+	t.Helper()
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Codec = payloadCodec
+	client, err := xrt.NewXrtClient(context.Background(), bus, prefix+"/request", prefix+"/reply", 200*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	return client.(*xrt.Client)
+}
+
+func TestRecordReplay(t *testing.T) {
+	tests := []struct {
+		name         string
+		codec        codec.Codec
+		payloadField string
+	}{
+		{"JSON", codec.JSON, `"payload":`},
+		{"CBOR", codec.CBOR, `"payload_base64":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := "capture/" + tt.name
+			ctx := context.Background()
+
+			bus := membus.NewMessageBus()
+			answer(t, bus, prefix+"/request", prefix+"/reply", tt.codec)
+			var capture bytes.Buffer
+			recorder := NewRecorder(bus, &capture)
+			client := newCaptureClient(t, recorder, prefix, tt.codec)
+			if _, err := client.AllDevices(ctx); err != nil {
+				t.Fatalf("AllDevices failed while recording: %v", err)
+			}
+			if _, err := client.DeviceByName(ctx, "device"); err != nil {
+				t.Fatalf("DeviceByName failed while recording: %v", err)
+			}
+			_ = client.Close()
+			if err := recorder.Err(); err != nil {
+				t.Fatalf("recording failed: %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(capture.String()), "\n")
+			if len(lines) != 4 {
+				t.Fatalf("capture holds %d entries, want the 2 requests and their replies", len(lines))
+			}
+			for _, line := range lines {
+				if !strings.Contains(line, tt.payloadField) {
+					t.Fatalf("capture entry %s lacks %s", line, tt.payloadField)
+				}
+			}
+			entries, err := ReadCapture(&capture)
+			if err != nil {
+				t.Fatalf("ReadCapture failed: %v", err)
+			}
+
+			replayer := NewReplayer(entries, tt.codec)
+			client = newCaptureClient(t, replayer, prefix, tt.codec)
+			defer client.Close()
+			// requests are matched by op and topic, not by their order
+			device, err := client.DeviceByName(ctx, "device")
+			if err != nil || device.ProfileName != "profile" {
+				t.Fatalf("replayed DeviceByName returned %+v, %v", device, err)
+			}
+			names, err := client.AllDevices(ctx)
+			if err != nil || !slices.Equal(names, []string{"device"}) {
+				t.Fatalf("replayed AllDevices returned %v, %v", names, err)
+			}
+			if remaining := replayer.Remaining(); len(remaining) != 0 {
+				t.Fatalf("replayer has %d recorded requests left, want none", len(remaining))
+			}
+			if _, err = client.AllDevices(ctx); err == nil {
+				t.Fatal("AllDevices succeeded without a recorded request left to replay")
+			}
+		})
+	}
+}
+
+func TestReplayRewritesRequestId(t *testing.T) {
+	entries := []Entry{
+		{Direction: DirectionPublish, Topic: "rewrite/request", Payload: []byte(`{"op":"device:list","request_id":"recorded"}`)},
+		{Direction: DirectionReceive, Topic: "rewrite/reply", Payload: []byte(`{"request_id":"recorded","result":{"status":0}}`)},
+	}
+	replayer := NewReplayer(entries, nil)
+	messages := make(chan types.MessageEnvelope, 1)
+	if err := replayer.SubscribeBinaryData([]types.TopicChannel{{Topic: "rewrite/reply", Messages: messages}}, nil); err != nil {
+		t.Fatalf("SubscribeBinaryData failed: %v", err)
+	}
+
+	if err := replayer.PublishBinaryData([]byte(`{"op":"device:list","request_id":"replayed"}`), "other/request"); err == nil {
+		t.Fatal("request on another topic was matched to the recorded request")
+	}
+	if err := replayer.PublishBinaryData([]byte(`{"op":"device:get","request_id":"replayed"}`), "rewrite/request"); err == nil {
+		t.Fatal("request of another op was matched to the recorded request")
+	}
+	if err := replayer.PublishBinaryData([]byte(`{"op":"device:list","request_id":"replayed"}`), "rewrite/request"); err != nil {
+		t.Fatalf("PublishBinaryData failed: %v", err)
+	}
+	select {
+	case message := <-messages:
+		var reply struct {
+			RequestId string `json:"request_id"`
+		}
+		if err := codec.JSON.Unmarshal(message.Payload.([]byte), &reply); err != nil || reply.RequestId != "replayed" {
+			t.Fatalf("replayed reply %s, want the requestId rewritten to replayed", message.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("recorded reply wasn't replayed")
+	}
+}
+
+// failingBus fails every subscription
+type failingBus struct {
+	*membus.MessageBus
+}
+
+func (failingBus) SubscribeBinaryData([]types.TopicChannel, chan error) error {
+	return stdErrors.New("subscription failed")
+}
+
+func (failingBus) Subscribe([]types.TopicChannel, chan error) error {
+	return stdErrors.New("subscription failed")
+}
+
+func TestRecorderFailedSubscription(t *testing.T) {
+	recorder := NewRecorder(failingBus{MessageBus: membus.NewMessageBus()}, &bytes.Buffer{})
+	topics := []types.TopicChannel{{Topic: "failed/a", Messages: make(chan types.MessageEnvelope)}, {Topic: "failed/b", Messages: make(chan types.MessageEnvelope)}}
+	if err := recorder.SubscribeBinaryData(topics, nil); err == nil {
+		t.Fatal("SubscribeBinaryData succeeded on a failing client")
+	}
+	if err := recorder.Subscribe(topics, nil); err == nil {
+		t.Fatal("Subscribe succeeded on a failing client")
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if len(recorder.done) != 0 {
+		t.Fatalf("recorder keeps forwarding %d topics of failed subscriptions", len(recorder.done))
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// Recorder is a messaging.MessageClient writing every message published through it, and every message delivered to
+// its subscribers, as an Entry line to the capture. Pass it to xrt.NewXrtClient in place of the wrapped client.
+type Recorder struct {
+	messaging.MessageClient
+
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	err     error
+	// done stops the forwarding goroutines of the subscriptions, keyed by topic filter
+	done map[string][]chan struct{}
+}
+
+var _ messaging.MessageClient = (*Recorder)(nil)
+
+// NewRecorder wraps the client, writing the capture to w
+func NewRecorder(client messaging.MessageClient, w io.Writer) *Recorder {
+	return &Recorder{
+		MessageClient: client,
+		encoder:       json.NewEncoder(w),
+		done:          make(map[string][]chan struct{}),
+	}
+}
+
+// Err returns the first error writing to the capture, entries after it may be missing
+func (r *Recorder) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.err
+}
+
+func (r *Recorder) Publish(message types.MessageEnvelope, topic string) error {
+	r.recordEnvelope(DirectionPublish, message, topic)
+	return r.MessageClient.Publish(message, topic)
+}
+
+func (r *Recorder) PublishWithSizeLimit(message types.MessageEnvelope, topic string, limit int64) error {
+	r.recordEnvelope(DirectionPublish, message, topic)
+	return r.MessageClient.PublishWithSizeLimit(message, topic, limit)
+}
+
+func (r *Recorder) PublishBinaryData(data []byte, topic string) error {
+	r.record(DirectionPublish, topic, data)
+	return r.MessageClient.PublishBinaryData(data, topic)
+}
+
+func (r *Recorder) Subscribe(topics []types.TopicChannel, messageErrors chan error) error {
+	interposed, stop := r.interpose(topics, false)
+	if err := r.MessageClient.Subscribe(interposed, messageErrors); err != nil {
+		stop()
+		return err
+	}
+	return nil
+}
+
+func (r *Recorder) SubscribeBinaryData(topics []types.TopicChannel, messageErrors chan error) error {
+	interposed, stop := r.interpose(topics, true)
+	if err := r.MessageClient.SubscribeBinaryData(interposed, messageErrors); err != nil {
+		stop()
+		return err
+	}
+	return nil
+}
+
+// Request records the request and its response
+func (r *Recorder) Request(message types.MessageEnvelope, requestTopic string, responseTopicPrefix string, timeout time.Duration) (*types.MessageEnvelope, error) {
+	r.recordEnvelope(DirectionPublish, message, requestTopic)
+	response, err := r.MessageClient.Request(message, requestTopic, responseTopicPrefix, timeout)
+	if err == nil && response != nil {
+		r.recordEnvelope(DirectionReceive, *response, response.ReceivedTopic)
+	}
+	return response, err
+}
+
+func (r *Recorder) Unsubscribe(topics ...string) error {
+	r.mutex.Lock()
+	for _, topic := range topics {
+		for _, done := range r.done[topic] {
+			close(done)
+		}
+		delete(r.done, topic)
+	}
+	r.mutex.Unlock()
+	return r.MessageClient.Unsubscribe(topics...)
+}
+
+func (r *Recorder) Disconnect() error {
+	r.mutex.Lock()
+	for topic, dones := range r.done {
+		for _, done := range dones {
+			close(done)
+		}
+		delete(r.done, topic)
+	}
+	r.mutex.Unlock()
+	return r.MessageClient.Disconnect()
+}
+
+// interpose replaces the channels of the topics with channels forwarding to them, recording each message on the way.
+// stop ends the forwarding again, for subscriptions which failed.
+func (r *Recorder) interpose(topics []types.TopicChannel, binary bool) (interposed []types.TopicChannel, stop func()) {
+	interposed = make([]types.TopicChannel, 0, len(topics))
+	dones := make([]chan struct{}, 0, len(topics))
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, topic := range topics {
+		messages := make(chan types.MessageEnvelope)
+		done := make(chan struct{})
+		r.done[topic.Topic] = append(r.done[topic.Topic], done)
+		dones = append(dones, done)
+		go r.forward(messages, topic.Messages, done, binary)
+		interposed = append(interposed, types.TopicChannel{Topic: topic.Topic, Messages: messages})
+	}
+	stop = func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		for i, topic := range topics {
+			// the done channel is gone if the topic was unsubscribed meanwhile
+			if j := slices.Index(r.done[topic.Topic], dones[i]); j >= 0 {
+				r.done[topic.Topic] = slices.Delete(r.done[topic.Topic], j, j+1)
+				close(dones[i])
+			}
+			if len(r.done[topic.Topic]) == 0 {
+				delete(r.done, topic.Topic)
+			}
+		}
+	}
+	return interposed, stop
+}
+
+func (r *Recorder) forward(in <-chan types.MessageEnvelope, out chan<- types.MessageEnvelope, done <-chan struct{}, binary bool) {
+	for {
+		select {
+		case <-done:
+			return
+		case message := <-in:
+			if binary {
+				r.recordBinary(message)
+			} else {
+				r.recordEnvelope(DirectionReceive, message, message.ReceivedTopic)
+			}
+			select {
+			case out <- message:
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+func (r *Recorder) recordBinary(message types.MessageEnvelope) {
+	payload, err := types.ConvertMsgPayloadToByteArray(message.ContentType, message.Payload)
+	if err != nil {
+		r.fail(fmt.Errorf("failed to record message from topic %s: %w", message.ReceivedTopic, err))
+		return
+	}
+	r.record(DirectionReceive, message.ReceivedTopic, payload)
+}
+
+func (r *Recorder) recordEnvelope(direction Direction, message types.MessageEnvelope, topic string) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		r.fail(fmt.Errorf("failed to record message envelope of topic %s: %w", topic, err))
+		return
+	}
+	r.record(direction, topic, data)
+}
+
+func (r *Recorder) record(direction Direction, topic string, payload []byte) {
+	entry := Entry{
+		Time:      time.Now(),
+		Direction: direction,
+		Topic:     topic,
+		Payload:   append([]byte(nil), payload...),
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err := r.encoder.Encode(entry); err != nil && r.err == nil {
+		r.err = fmt.Errorf("failed to write capture entry: %w", err)
+	}
+}
+
+func (r *Recorder) fail(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
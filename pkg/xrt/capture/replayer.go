@@ -0,0 +1,243 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/internal/membus"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// Replayer is a messaging.MessageClient answering the requests of a client with the replies of a capture, so the
+// client re-executes the recorded session without a broker or XRT node.
+//
+// Every request published by the client is matched to the first recorded request on the same topic with the same op
+// that hasn't been replayed yet. The recorded replies of that request are delivered immediately, with the requestId
+// rewritten to the one of the new request. Received messages that aren't replies, such as status or discovery
+// events, are delivered in capture order once the recorded request preceding them has been replayed.
+type Replayer struct {
+	bus   *membus.MessageBus
+	codec codec.Codec
+
+	mutex    sync.Mutex
+	entries  []Entry
+	requests []*recordedRequest
+	// unsolicited are the indexes of the received entries which aren't replies to a recorded request
+	unsolicited []int
+	delivered   map[int]bool
+	filters     []string
+}
+
+// recordedRequest is a request of the capture and the indexes of its replies
+type recordedRequest struct {
+	index    int
+	op       string
+	replies  []int
+	replayed bool
+}
+
+// envelope holds the fields of XRT requests and replies the Replayer needs
+type envelope struct {
+	Op        string `json:"op"`
+	RequestId string `json:"request_id"`
+}
+
+var _ messaging.MessageClient = (*Replayer)(nil)
+
+// NewReplayer creates a Replayer for the capture entries. The codec must match the one of the recorded client and be
+// a codec.FieldSetter to rewrite the requestIds of the replies, it defaults to codec.JSON.
+func NewReplayer(entries []Entry, payloadCodec codec.Codec) *Replayer {
+	r := &Replayer{
+		bus:       membus.NewMessageBus(),
+		codec:     codec.OrDefault(payloadCodec),
+		entries:   entries,
+		delivered: make(map[int]bool),
+	}
+
+	pending := make(map[string]*recordedRequest)
+	for i, entry := range entries {
+		var fields envelope
+		_ = r.codec.Unmarshal(entry.Payload, &fields)
+		switch entry.Direction {
+		case DirectionPublish:
+			request := &recordedRequest{index: i, op: fields.Op}
+			r.requests = append(r.requests, request)
+			if fields.RequestId != "" {
+				pending[fields.RequestId] = request
+			}
+		case DirectionReceive:
+			if request, ok := pending[fields.RequestId]; ok && fields.RequestId != "" {
+				request.replies = append(request.replies, i)
+				continue
+			}
+			r.unsolicited = append(r.unsolicited, i)
+		}
+	}
+	return r
+}
+
+// Remaining returns the recorded requests which haven't been replayed yet
+func (r *Replayer) Remaining() []Entry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var remaining []Entry
+	for _, request := range r.requests {
+		if !request.replayed {
+			remaining = append(remaining, r.entries[request.index])
+		}
+	}
+	return remaining
+}
+
+func (r *Replayer) Connect() error {
+	return nil
+}
+
+// Publish replays the JSON-encoded envelope like PublishBinaryData, the way the Recorder records it
+func (r *Replayer) Publish(message types.MessageEnvelope, topic string) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message envelope: %w", err)
+	}
+	return r.PublishBinaryData(data, topic)
+}
+
+func (r *Replayer) PublishWithSizeLimit(message types.MessageEnvelope, topic string, _ int64) error {
+	return r.Publish(message, topic)
+}
+
+// PublishBinaryData matches the request to a recorded one and delivers the recorded replies. It fails if no recorded
+// request is left to match.
+func (r *Replayer) PublishBinaryData(data []byte, topic string) error {
+	var fields envelope
+	_ = r.codec.Unmarshal(data, &fields)
+
+	r.mutex.Lock()
+	idx := slices.IndexFunc(r.requests, func(request *recordedRequest) bool {
+		return !request.replayed && request.op == fields.Op && r.entries[request.index].Topic == topic
+	})
+	if idx < 0 {
+		r.mutex.Unlock()
+		return fmt.Errorf("no recorded request %q on topic %s left to replay", fields.Op, topic)
+	}
+	request := r.requests[idx]
+	request.replayed = true
+
+	deliveries := slices.Clone(request.replies)
+	deliveries = append(deliveries, r.releaseUnsolicited()...)
+	slices.Sort(deliveries)
+	r.mutex.Unlock()
+
+	var errs []error
+	for _, i := range deliveries {
+		entry := r.entries[i]
+		payload := entry.Payload
+		if slices.Contains(request.replies, i) {
+			rewritten, err := r.rewriteRequestId(payload, fields.RequestId)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			payload = rewritten
+		}
+		errs = append(errs, r.bus.PublishBinaryData(payload, entry.Topic))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Replayer) Subscribe(topics []types.TopicChannel, messageErrors chan error) error {
+	if err := r.bus.Subscribe(topics, messageErrors); err != nil {
+		return err
+	}
+	return r.subscribed(topics)
+}
+
+func (r *Replayer) SubscribeBinaryData(topics []types.TopicChannel, messageErrors chan error) error {
+	if err := r.bus.SubscribeBinaryData(topics, messageErrors); err != nil {
+		return err
+	}
+	return r.subscribed(topics)
+}
+
+// Request isn't used by xrt.Client and can't be replayed
+func (r *Replayer) Request(types.MessageEnvelope, string, string, time.Duration) (*types.MessageEnvelope, error) {
+	return nil, errors.New("request-reply messaging is not supported by the replayer")
+}
+
+func (r *Replayer) Unsubscribe(topics ...string) error {
+	r.mutex.Lock()
+	r.filters = slices.DeleteFunc(r.filters, func(filter string) bool { return slices.Contains(topics, filter) })
+	r.mutex.Unlock()
+	return r.bus.Unsubscribe(topics...)
+}
+
+func (r *Replayer) Disconnect() error {
+	r.mutex.Lock()
+	r.filters = nil
+	r.mutex.Unlock()
+	return r.bus.Disconnect()
+}
+
+// subscribed delivers the unsolicited messages which became deliverable with the new subscriptions
+func (r *Replayer) subscribed(topics []types.TopicChannel) error {
+	r.mutex.Lock()
+	for _, topic := range topics {
+		r.filters = append(r.filters, topic.Topic)
+	}
+	deliveries := r.releaseUnsolicited()
+	r.mutex.Unlock()
+
+	var errs []error
+	for _, i := range deliveries {
+		errs = append(errs, r.bus.PublishBinaryData(r.entries[i].Payload, r.entries[i].Topic))
+	}
+	return errors.Join(errs...)
+}
+
+// releaseUnsolicited returns the undelivered unsolicited messages whose preceding request has been replayed and which
+// have a subscriber, and marks them delivered. The caller must hold the mutex.
+func (r *Replayer) releaseUnsolicited() []int {
+	var released []int
+	for _, i := range r.unsolicited {
+		if r.delivered[i] || !r.deliverable(i) {
+			continue
+		}
+		r.delivered[i] = true
+		released = append(released, i)
+	}
+	return released
+}
+
+func (r *Replayer) deliverable(i int) bool {
+	for _, request := range slices.Backward(r.requests) {
+		if request.index < i {
+			if !request.replayed {
+				return false
+			}
+			break
+		}
+	}
+	return slices.ContainsFunc(r.filters, func(filter string) bool { return membus.TopicMatches(filter, r.entries[i].Topic) })
+}
+
+// rewriteRequestId replaces the requestId of the recorded reply, passing its other fields through as recorded
+func (r *Replayer) rewriteRequestId(payload []byte, requestId string) ([]byte, error) {
+	setter, ok := r.codec.(codec.FieldSetter)
+	if !ok {
+		return nil, fmt.Errorf("codec %s can't rewrite the requestId of recorded replies", r.codec.Name())
+	}
+	rewritten, err := setter.SetField(payload, "request_id", requestId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite the requestId of recorded reply: %w", err)
+	}
+	return rewritten, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package codec provides the payload encodings of XRT requests and replies
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Codec encodes XRT requests and decodes XRT replies. Both ends of a request and reply topic must use the same codec.
+type Codec interface {
+	// Name identifies the encoding, codecs with the same name are considered interchangeable
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// FieldSetter is implemented by codecs which can set a top-level field of an encoded object while passing its other
+// fields through as encoded, so numbers keep their precision. The built-in codecs implement it.
+type FieldSetter interface {
+	// SetField returns the encoded object with the field set to value, data must encode an object
+	SetField(data []byte, field string, value any) ([]byte, error)
+}
+
+var (
+	_ FieldSetter = jsonCodec{}
+	_ FieldSetter = cborCodec{}
+)
+
+var (
+	// JSON encodes payloads with encoding/json, it is the default codec
+	JSON Codec = jsonCodec{}
+	// CBOR encodes payloads as CBOR (RFC 8949), honoring the json struct tags of the XRT models
+	CBOR Codec = newCBORCodec()
+)
+
+var errNotAnObject = errors.New("encoded value is not an object")
+
+// OrDefault returns the codec, or JSON if it is nil
+func OrDefault(codec Codec) Codec {
+	if codec == nil {
+		return JSON
+	}
+	return codec
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) SetField(data []byte, field string, value any) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, errNotAnObject
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields[field] = encoded
+	return json.Marshal(fields)
+}
+
+type cborCodec struct {
+	encMode cbor.EncMode
+	decMode cbor.DecMode
+}
+
+func newCBORCodec() cborCodec {
+	encMode, err := cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	// decode maps to map[string]any rather than map[any]any, so generic values look the same as with JSON
+	decMode, err := cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]any(nil))}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return cborCodec{encMode: encMode, decMode: decMode}
+}
+
+func (cborCodec) Name() string {
+	return "cbor"
+}
+
+func (c cborCodec) Marshal(v any) ([]byte, error) {
+	return c.encMode.Marshal(v)
+}
+
+func (c cborCodec) Unmarshal(data []byte, v any) error {
+	return c.decMode.Unmarshal(data, v)
+}
+
+func (c cborCodec) SetField(data []byte, field string, value any) ([]byte, error) {
+	var fields map[string]cbor.RawMessage
+	if err := c.decMode.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, errNotAnObject
+	}
+	encoded, err := c.encMode.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields[field] = encoded
+	return c.encMode.Marshal(fields)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package codec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSetFieldKeepsPrecision(t *testing.T) {
+	type request struct {
+		RequestId string `json:"request_id"`
+		Big       int64  `json:"big"`
+		Unsigned  uint64 `json:"unsigned"`
+	}
+	for _, codec := range []Codec{JSON, CBOR} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			data, err := codec.Marshal(request{RequestId: "1", Big: math.MaxInt64, Unsigned: math.MaxUint64})
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			data, err = codec.(FieldSetter).SetField(data, "request_id", "2")
+			if err != nil {
+				t.Fatalf("SetField failed: %v", err)
+			}
+
+			var decoded request
+			if err = codec.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded != (request{RequestId: "2", Big: math.MaxInt64, Unsigned: math.MaxUint64}) {
+				t.Fatalf("SetField changed the request to %+v", decoded)
+			}
+		})
+	}
+}
+
+func TestSetFieldRejectsNonObjects(t *testing.T) {
+	for _, codec := range []Codec{JSON, CBOR} {
+		data, err := codec.Marshal([]int{1})
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if _, err = codec.(FieldSetter).SetField(data, "field", 1); err == nil {
+			t.Fatalf("%s SetField succeeded on an array", codec.Name())
+		}
+		null, _ := codec.Marshal(nil)
+		if _, err = codec.(FieldSetter).SetField(null, "field", 1); err == nil {
+			t.Fatalf("%s SetField succeeded on null", codec.Name())
+		}
+	}
+}
@@ -4,15 +4,34 @@ package xrt
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
 
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
 	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/topicmgr"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 )
 
+// StopCondition reports whether a reply stream should end after the given reply.
+// received is the number of replies received so far, including this one.
+type StopCondition[T any] func(received int, reply T) bool
+
+// StopAfter ends the reply stream once n replies have been received
+func StopAfter[T any](n int) StopCondition[T] {
+	return func(received int, _ T) bool {
+		return received >= n
+	}
+}
+
+// StopWhen ends the reply stream after the first reply matching the predicate
+func StopWhen[T any](predicate func(reply T) bool) StopCondition[T] {
+	return func(_ int, reply T) bool {
+		return predicate(reply)
+	}
+}
+
 func FetchXRTResponse(ctx context.Context, requestId string, requestMap topicmgr.RequestMap, responseTimeout time.Duration) ([]byte, errors.EdgeX) {
 	resChan, ok := requestMap.Get(requestId)
 	if !ok {
@@ -25,9 +44,10 @@ func FetchXRTResponse(ctx context.Context, requestId string, requestMap topicmgr
 	timeout := time.After(responseTimeout)
 	select {
 	case <-ctx.Done():
-		return nil, nil
+		ctxErr := contextError(ctx)
+		return nil, errors.NewCommonEdgeX(errors.Kind(ctxErr), fmt.Sprintf("stopped fetching command response, requestId: %s", requestId), ctxErr)
 	case <-timeout:
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, "timed out fetching command response", nil)
+		return nil, errors.NewCommonEdgeX(KindTimeout, fmt.Sprintf("timed out fetching command response, requestId: %s", requestId), ErrTimeout)
 	case commandResponse := <-resChan:
 		return commandResponse, nil
 	}
@@ -35,7 +55,20 @@ func FetchXRTResponse(ctx context.Context, requestId string, requestMap topicmgr
 
 // FetchXRTResWithSubTimeout subscribe multiple messages of the same requestId for the given subscribe timeout, and the result will be appended in the response slice
 // After the subscribe timeout, the response slice pointer will be returned
+// The replies are decoded as JSON, use FetchXRTResWithSubTimeoutCodec for clients with another codec.
+//
+// Deprecated: use StreamXRTResWithSubTimeout, which delivers the replies as they arrive and supports early exit.
 func FetchXRTResWithSubTimeout(ctx context.Context, requestId string, requestMap topicmgr.RequestMap, subscribeTimeout time.Duration, response any) errors.EdgeX {
+	return FetchXRTResWithSubTimeoutCodec(ctx, requestId, requestMap, subscribeTimeout, codec.JSON, response)
+}
+
+// FetchXRTResWithSubTimeoutCodec is FetchXRTResWithSubTimeout decoding the replies with replyCodec, which should be
+// the codec of the client sending the request; nil means JSON.
+//
+// Deprecated: use StreamXRTResWithSubTimeout, which delivers the replies as they arrive and supports early exit.
+func FetchXRTResWithSubTimeoutCodec(ctx context.Context, requestId string, requestMap topicmgr.RequestMap, subscribeTimeout time.Duration,
+	replyCodec codec.Codec, response any) errors.EdgeX {
+	replyCodec = codec.OrDefault(replyCodec)
 	resChan, ok := requestMap.Get(requestId)
 	if !ok {
 		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("the corresponding ResponseChan not found by requestId %s", requestId), nil)
@@ -58,7 +91,8 @@ func FetchXRTResWithSubTimeout(ctx context.Context, requestId string, requestMap
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			ctxErr := contextError(ctx)
+			return errors.NewCommonEdgeX(errors.Kind(ctxErr), fmt.Sprintf("stopped fetching command responses, requestId: %s", requestId), ctxErr)
 		case <-subTimeout:
 			// set the respSlice slice back to the response interface
 			reflect.ValueOf(response).Elem().Set(respSlice)
@@ -68,7 +102,7 @@ func FetchXRTResWithSubTimeout(ctx context.Context, requestId string, requestMap
 			element := reflect.New(sliceType.Elem())
 			tmp := element.Interface()
 			// unmarshal the commandResponse bytes to the tmp pointer
-			err := json.Unmarshal(commandResponse, tmp)
+			err := replyCodec.Unmarshal(commandResponse, tmp)
 			if err != nil {
 				return errors.NewCommonEdgeX(errors.KindServerError,
 					fmt.Sprintf("failed to unmarshal response bytes from message bus to element: %v", element.Type()), nil)
@@ -79,3 +113,81 @@ func FetchXRTResWithSubTimeout(ctx context.Context, requestId string, requestMap
 		}
 	}
 }
+
+// StreamXRTResWithSubTimeout subscribes multiple messages of the same requestId and sends each of them, decoded to T,
+// on the returned channel as soon as it arrives. The channel is closed when the subscribe timeout elapses, the stop
+// condition matches or ctx is done; in the latter case, callers should report ctx.Err() rather than treat the replies
+// received so far as complete. stop may be nil. Replies are decoded with replyCodec, which should be the codec of
+// the client sending the request, nil means JSON; those that can't be decoded to T are logged and skipped.
+func StreamXRTResWithSubTimeout[T any](ctx context.Context, requestId string, requestMap topicmgr.RequestMap, subscribeTimeout time.Duration,
+	replyCodec codec.Codec, stop StopCondition[T], lc logger.LoggingClient) (<-chan T, errors.EdgeX) {
+	if _, ok := requestMap.Get(requestId); !ok {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("the corresponding ResponseChan not found by requestId %s", requestId), nil)
+	}
+
+	forwarder := newReplyForwarder(stop, codec.OrDefault(replyCodec), lc)
+	go func() {
+		defer close(forwarder.replies)
+		_ = receiveXRTReplies(ctx, requestId, requestMap, subscribeTimeout, forwarder.forward)
+	}()
+	return forwarder.replies, nil
+}
+
+// receiveXRTReplies passes multiple messages of the same requestId to onReply until the subscribe timeout elapses,
+// ctx is done or onReply returns false. onReply gets a context which is done once the subscribe timeout elapses.
+func receiveXRTReplies(ctx context.Context, requestId string, requestMap topicmgr.RequestMap, subscribeTimeout time.Duration,
+	onReply func(ctx context.Context, reply []byte) bool) errors.EdgeX {
+	resChan, ok := requestMap.Get(requestId)
+	if !ok {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("the corresponding ResponseChan not found by requestId %s", requestId), nil)
+	}
+	defer requestMap.Delete(requestId)
+
+	subCtx, cancel := context.WithTimeout(ctx, subscribeTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-subCtx.Done():
+			if ctx.Err() != nil {
+				return contextError(ctx)
+			}
+			return nil
+		case commandResponse := <-resChan:
+			if !onReply(subCtx, commandResponse) {
+				return nil
+			}
+		}
+	}
+}
+
+// replyForwarder decodes the replies of a multi-reply request to T and sends them on its channel until the stop
+// condition matches
+type replyForwarder[T any] struct {
+	replies  chan T
+	stop     StopCondition[T]
+	codec    codec.Codec
+	received int
+	lc       logger.LoggingClient
+}
+
+func newReplyForwarder[T any](stop StopCondition[T], replyCodec codec.Codec, lc logger.LoggingClient) *replyForwarder[T] {
+	return &replyForwarder[T]{replies: make(chan T), stop: stop, codec: replyCodec, lc: lc}
+}
+
+// forward decodes and sends the reply, it returns false once no further replies should be forwarded
+func (f *replyForwarder[T]) forward(ctx context.Context, commandResponse []byte) bool {
+	var reply T
+	if err := f.codec.Unmarshal(commandResponse, &reply); err != nil {
+		f.lc.Warnf("failed to unmarshal XRT reply to %T, err: %v", reply, err)
+		return true
+	}
+	f.received++
+
+	select {
+	case f.replies <- reply:
+	case <-ctx.Done():
+		return false
+	}
+	return f.stop == nil || !f.stop(f.received, reply)
+}
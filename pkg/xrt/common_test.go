@@ -0,0 +1,75 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/topicmgr"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+type testReply struct {
+	Node string `json:"node"`
+}
+
+// newTestReplies returns a RequestMap holding the given replies, encoded with replyCodec, for requestId
+func newTestReplies(t *testing.T, requestId string, replyCodec codec.Codec, nodes ...string) topicmgr.RequestMap {
+	t.Helper()
+	requestMap := topicmgr.NewRequestMap()
+	requestMap.Add(requestId, uint(len(nodes)))
+	resChan, _ := requestMap.Get(requestId)
+	for _, node := range nodes {
+		reply, err := replyCodec.Marshal(testReply{Node: node})
+		if err != nil {
+			t.Fatalf("failed to encode reply: %v", err)
+		}
+		resChan <- reply
+	}
+	return requestMap
+}
+
+func TestFetchXRTResWithSubTimeout(t *testing.T) {
+	requestMap := newTestReplies(t, "fetch", codec.JSON, "node-1", "node-2")
+
+	var replies []testReply
+	if err := FetchXRTResWithSubTimeout(context.Background(), "fetch", requestMap, 50*time.Millisecond, &replies); err != nil {
+		t.Fatalf("FetchXRTResWithSubTimeout failed: %v", err)
+	}
+	if !slices.Equal(replies, []testReply{{Node: "node-1"}, {Node: "node-2"}}) {
+		t.Fatalf("FetchXRTResWithSubTimeout returned %v", replies)
+	}
+}
+
+func TestFetchXRTResWithSubTimeoutCodec(t *testing.T) {
+	requestMap := newTestReplies(t, "fetch", codec.CBOR, "node-1", "node-2")
+
+	var replies []testReply
+	if err := FetchXRTResWithSubTimeoutCodec(context.Background(), "fetch", requestMap, 50*time.Millisecond, codec.CBOR, &replies); err != nil {
+		t.Fatalf("FetchXRTResWithSubTimeoutCodec failed: %v", err)
+	}
+	if !slices.Equal(replies, []testReply{{Node: "node-1"}, {Node: "node-2"}}) {
+		t.Fatalf("FetchXRTResWithSubTimeoutCodec returned %v", replies)
+	}
+}
+
+func TestStreamXRTResWithSubTimeoutCodec(t *testing.T) {
+	requestMap := newTestReplies(t, "stream", codec.CBOR, "node-1", "node-2", "node-3")
+
+	replies, err := StreamXRTResWithSubTimeout(context.Background(), "stream", requestMap, time.Second, codec.CBOR,
+		StopAfter[testReply](2), logger.NewMockClient())
+	if err != nil {
+		t.Fatalf("StreamXRTResWithSubTimeout failed: %v", err)
+	}
+	var received []testReply
+	for reply := range replies {
+		received = append(received, reply)
+	}
+	if !slices.Equal(received, []testReply{{Node: "node-1"}, {Node: "node-2"}}) {
+		t.Fatalf("StreamXRTResWithSubTimeout delivered %v", received)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/edgeclienttest"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// newConformanceClient creates a client for the environment with the options of edgeclienttest.NewXrtClient, adjusted
+// by configure
+func newConformanceClient(t *testing.T, env edgeclienttest.Environment, configure func(options *xrt.ClientOptions)) *xrt.Client {
+	t.Helper()
+	options := xrt.NewClientOptions(
+		xrt.NewCommandOptions(env.CommandTopic, "", nil),
+		xrt.NewDiscoveryOptions(env.DiscoveryTopic, nil, env.ResponseTimeout, nil, 0),
+		nil)
+	options.Codec = env.Codec
+	configure(options)
+	client, err := xrt.NewXrtClient(context.Background(), env.MessageBus, env.RequestTopic, env.ReplyTopic,
+		env.ResponseTimeout, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	return client.(*xrt.Client)
+}
+
+// configured returns a Factory of clients configured by configure
+func configured(configure func(options *xrt.ClientOptions)) edgeclienttest.Factory {
+	return func(t *testing.T, env edgeclienttest.Environment) interfaces.EdgeClient {
+		return newConformanceClient(t, env, configure)
+	}
+}
+
+// nodeView is the view of a client addressing a single node, which closes the client it was created from
+type nodeView struct {
+	*xrt.Client
+	parent *xrt.Client
+}
+
+func (v nodeView) Close() errors.EdgeX {
+	return v.parent.Close()
+}
+
+// relayNodeTopic forwards the messages of the node topic to the shared topic the nodes of the environment receive on
+func relayNodeTopic(t *testing.T, env edgeclienttest.Environment, nodeTopic string, sharedTopic string) {
+	t.Helper()
+	messages := make(chan types.MessageEnvelope)
+	if err := env.MessageBus.SubscribeBinaryData([]types.TopicChannel{{Topic: nodeTopic, Messages: messages}}, nil); err != nil {
+		t.Fatalf("failed to subscribe to %s: %v", nodeTopic, err)
+	}
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+		_ = env.MessageBus.Unsubscribe(nodeTopic)
+	})
+	go func() {
+		for {
+			select {
+			case message := <-messages:
+				_ = env.MessageBus.PublishBinaryData(message.Payload.([]byte), sharedTopic)
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func newNodeView(t *testing.T, env edgeclienttest.Environment) interfaces.EdgeClient {
+	t.Helper()
+	// the node answering every request of the environment
+	nodeID := "node-1"
+	client := newConformanceClient(t, env, func(options *xrt.ClientOptions) {
+		options.Nodes = xrt.NewNodeOptions(env.RequestTopic+"/"+xrt.NodeIDPlaceholder, env.CommandTopic+"/"+xrt.NodeIDPlaceholder)
+	})
+	relayNodeTopic(t, env, env.RequestTopic+"/"+nodeID, env.RequestTopic)
+	relayNodeTopic(t, env, env.CommandTopic+"/"+nodeID, env.CommandTopic)
+	view, err := client.ForNode(nodeID)
+	if err != nil {
+		t.Fatalf("ForNode failed: %v", err)
+	}
+	return nodeView{Client: view, parent: client}
+}
+
+func TestConformance(t *testing.T) {
+	edgeclienttest.RunConformance(t, edgeclienttest.NewXrtClient)
+}
+
+func TestConformanceCollapseReads(t *testing.T) {
+	edgeclienttest.RunConformance(t, configured(func(options *xrt.ClientOptions) {
+		options.CollapseReads = true
+	}))
+}
+
+func TestConformanceLimits(t *testing.T) {
+	edgeclienttest.RunConformance(t, configured(func(options *xrt.ClientOptions) {
+		options.Limits = &xrt.Limits{
+			MaxInFlightPerTopic: 4,
+			RateLimits:          map[xrt.OpCategory]xrt.RateLimit{xrt.CategoryMetadata: {Rate: 1000, Burst: 10}},
+			MaxQueueDepth:       16,
+		}
+	}))
+}
+
+func TestConformanceCircuitBreaker(t *testing.T) {
+	edgeclienttest.RunConformance(t, configured(func(options *xrt.ClientOptions) {
+		options.CircuitBreaker = xrt.NewCircuitBreaker(5, 50*time.Millisecond)
+	}))
+}
+
+func TestConformanceRetryPolicy(t *testing.T) {
+	edgeclienttest.RunConformance(t, configured(func(options *xrt.ClientOptions) {
+		options.RetryPolicy = xrt.NewRetryPolicy(2, 10*time.Millisecond, 0)
+	}))
+}
+
+func TestConformanceCBOR(t *testing.T) {
+	edgeclienttest.RunConformanceWithOptions(t, edgeclienttest.NewXrtClient, edgeclienttest.Options{Codec: codec.CBOR})
+}
+
+func TestConformanceForNode(t *testing.T) {
+	edgeclienttest.RunConformanceWithOptions(t, newNodeView, edgeclienttest.Options{SingleNode: true})
+}
+
+func TestConformanceOutbox(t *testing.T) {
+	edgeclienttest.RunConformance(t, configured(func(options *xrt.ClientOptions) {
+		outbox, err := xrt.NewOutbox(t.TempDir(), time.Hour)
+		if err != nil {
+			t.Fatalf("NewOutbox failed: %v", err)
+		}
+		options.Outbox = outbox
+	}))
+}
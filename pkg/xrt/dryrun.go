@@ -0,0 +1,98 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// DryRunRequest is a request the client would have published
+type DryRunRequest struct {
+	Time      time.Time
+	Op        string
+	Topic     string
+	RequestId string
+	// Payload is the encoded request, exactly as it would have been published
+	Payload []byte
+}
+
+// DryRunInspector puts a client in dry-run mode: requests are built, encoded and passed through the interceptors as
+// usual, but recorded by the inspector instead of being published. Read-only operations, see IsReadOnlyOp, and other
+// operations returning data, like device:scan, fail with ErrDryRun; all other operations succeed with an empty result.
+// The AuditLog marks the records of operations sent in dry-run mode, see AuditRecord.DryRun.
+type DryRunInspector struct {
+	// OnRequest is called for every recorded request, it must not block
+	OnRequest func(request DryRunRequest)
+
+	mutex    sync.Mutex
+	requests []DryRunRequest
+}
+
+// NewDryRunInspector creates a DryRunInspector, set it as ClientOptions.DryRun to enable the dry-run mode
+func NewDryRunInspector() *DryRunInspector {
+	return &DryRunInspector{}
+}
+
+// Requests returns the requests recorded so far, in the order they would have been published
+func (i *DryRunInspector) Requests() []DryRunRequest {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return append([]DryRunRequest(nil), i.requests...)
+}
+
+// Reset discards the recorded requests
+func (i *DryRunInspector) Reset() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.requests = nil
+}
+
+func (i *DryRunInspector) record(request DryRunRequest) {
+	i.mutex.Lock()
+	i.requests = append(i.requests, request)
+	i.mutex.Unlock()
+	if i.OnRequest != nil {
+		i.OnRequest(request)
+	}
+}
+
+// resultOps are the operations which aren't read-only, but whose reply carries data for the caller
+var resultOps = map[string]bool{
+	OpDeviceScan: true,
+}
+
+// dryRun records the payload of the call in place of publishing it and returns the reply of the dry run
+func (c *Client) dryRun(call *Call, payload []byte) ([][]byte, errors.EdgeX) {
+	c.clientOptions.DryRun.record(DryRunRequest{
+		Time:      time.Now(),
+		Op:        call.Op,
+		Topic:     call.Topic,
+		RequestId: call.RequestId,
+		Payload:   payload,
+	})
+	if IsReadOnlyOp(call.Op) || resultOps[call.Op] {
+		return nil, errors.NewCommonEdgeX(errors.KindNotAllowed, fmt.Sprintf("XRT request %s has no reply in dry-run mode", call.Op), ErrDryRun)
+	}
+
+	result := map[string]any{"status": StatusOK}
+	if c.nodeID != "" {
+		result["node"] = c.nodeID
+	}
+	reply, err := c.codec.Marshal(map[string]any{
+		"client":     clientName,
+		"request_id": call.RequestId,
+		"result":     result,
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to %s encode the dry-run reply", c.codec.Name()), err)
+	}
+	return [][]byte{reply}, nil
+}
+
+func (c *Client) dryRunEnabled() bool {
+	return c.clientOptions != nil && c.clientOptions.DryRun != nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+)
+
+func TestDryRun(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "dryrun/request", ReplyTopic: "dryrun/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	sink := &recordingSink{}
+	inspector := xrt.NewDryRunInspector()
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.AuditLog = xrt.NewAuditLog(sink)
+	options.DryRun = inspector
+	client, err := xrt.NewXrtClient(context.Background(), bus, "dryrun/request", "dryrun/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed in dry-run mode: %v", err)
+	}
+	if _, err = client.AllDevices(ctx); !stdErrors.Is(err, xrt.ErrDryRun) {
+		t.Fatalf("AllDevices returned %v in dry-run mode, want ErrDryRun", err)
+	}
+	device := dtos.Device{Name: "device", ProfileName: "profile"}
+	if name, err := client.(*xrt.Client).ScanDeviceWithResult(ctx, device, nil, 100*time.Millisecond); !stdErrors.Is(err, xrt.ErrDryRun) {
+		t.Fatalf("ScanDeviceWithResult returned %q, %v in dry-run mode, want ErrDryRun", name, err)
+	}
+
+	if requests := inspector.Requests(); len(requests) != 3 {
+		t.Fatalf("inspector recorded %d requests, want 3", len(requests))
+	}
+	if received := node.Requests(); len(received) != 0 {
+		t.Fatalf("node received %d requests in dry-run mode", len(received))
+	}
+	if health := client.(*xrt.Client).Health(); !health.LastRoundTrip.IsZero() {
+		t.Fatalf("health reports a round trip at %v in dry-run mode", health.LastRoundTrip)
+	}
+	records := sink.Records()
+	if len(records) != 1 || !records[0].DryRun || records[0].Op != xrt.OpProfileAdd {
+		t.Fatalf("audited %+v, want the profile:add marked as dry run", records)
+	}
+}
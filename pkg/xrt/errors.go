@@ -0,0 +1,187 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// Error kinds of request outcomes which are decided on the client side rather than reported by XRT
+const (
+	// KindTimeout is the kind of errors caused by a response timeout or an expired context deadline
+	KindTimeout = errors.KindServiceUnavailable
+	// KindCanceled is the kind of errors caused by a cancelled context. It is specific to the client, so cancelled
+	// calls can't be mistaken for failures of the message bus, and maps to the HTTP status code 500.
+	KindCanceled errors.ErrKind = "Canceled"
+	// KindCircuitOpen is the kind of errors of requests rejected by an open CircuitBreaker. It is specific to the client,
+	// so rejected requests can't be mistaken for timed out ones, and maps to the HTTP status code 500.
+	KindCircuitOpen errors.ErrKind = "CircuitOpen"
+	// KindQueued is the kind of errors of requests queued by the Outbox. It is specific to the client, so queued
+	// requests can't be mistaken for timed out ones, and maps to the HTTP status code 500.
+	KindQueued errors.ErrKind = "Queued"
+)
+
+// Result status codes reported by XRT in result.status, which follow errno
+const (
+	StatusOK            = 0
+	StatusNotFound      = 2   // ENOENT
+	StatusInUse         = 16  // EBUSY
+	StatusAlreadyExists = 17  // EEXIST
+	StatusInvalid       = 22  // EINVAL
+	StatusOutOfRange    = 34  // ERANGE
+	StatusTimedOut      = 110 // ETIMEDOUT
+)
+
+// Sentinel errors of failed XRT requests, use errors.Is to check an error returned by the client against them.
+// They are plain Go errors because errors.Is matches any EdgeX error against a CommonEdgeX target.
+var (
+	// ErrNotFound is reported when the device, profile, schedule, resource or component doesn't exist
+	ErrNotFound = stdErrors.New("XRT entity not found")
+	// ErrAlreadyExists is reported when adding an entity whose name is already taken
+	ErrAlreadyExists = stdErrors.New("XRT entity already exists")
+	// ErrInUse is reported when an entity can't be removed because another one references it
+	ErrInUse = stdErrors.New("XRT entity in use")
+	// ErrValidation is reported when XRT or the device driver rejects the request or the values within it
+	ErrValidation = stdErrors.New("XRT request invalid")
+	// ErrTimeout is reported when XRT or the client gives up waiting, including an expired context deadline
+	ErrTimeout = stdErrors.New("XRT request timed out")
+	// ErrLimitExceeded is reported when the client's Limits reject the request or drop it from the queue
+	ErrLimitExceeded = stdErrors.New("XRT request limit exceeded")
+	// ErrCircuitOpen is reported while the CircuitBreaker rejects the requests to an unresponsive request topic
+	ErrCircuitOpen = stdErrors.New("XRT circuit open")
+	// ErrDryRun is reported for read-only requests of a client in dry-run mode, which has no replies to return
+	ErrDryRun = stdErrors.New("XRT request not sent in dry-run mode")
+	// ErrPublishFailed is reported when the request couldn't be published to the message bus
+	ErrPublishFailed = stdErrors.New("XRT request not published")
+	// ErrQueued is reported when the Outbox queued the request to send it once the message bus is available again,
+	// use errors.As with a *QueuedError to get the id of the queued operation
+	ErrQueued = stdErrors.New("XRT request queued")
+	// ErrNodeMismatch is reported when the reply to a request of a node view came from another node, see Client.ForNode
+	ErrNodeMismatch = stdErrors.New("XRT reply from another node")
+	// ErrNotReady is reported by Ping while the reply subscription is inactive or no XRT node has reported online status
+	ErrNotReady = stdErrors.New("XRT client not ready")
+	// ErrUnsupported is reported when the version of the addressed XRT nodes doesn't support the request, see
+	// CapabilityOptions
+	ErrUnsupported = stdErrors.New("XRT request unsupported")
+)
+
+// XRTError is an error result reported by XRT, use errors.As to retrieve it from an error returned by the client
+type XRTError struct {
+	// Status is the result status code reported by XRT
+	Status int
+	// Message is the error message reported by XRT
+	Message string
+	// Op is the operation of the failed request
+	Op string
+	// RequestId is the requestId of the failed request
+	RequestId string
+}
+
+func (e *XRTError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("XRT request %s failed with status %d", e.Op, e.Status)
+	}
+	return e.Message
+}
+
+// Unwrap returns the sentinel error matching the status, or nil if there is none
+func (e *XRTError) Unwrap() error {
+	switch e.Status {
+	case StatusNotFound:
+		return ErrNotFound
+	case StatusAlreadyExists:
+		return ErrAlreadyExists
+	case StatusInUse:
+		return ErrInUse
+	case StatusInvalid, StatusOutOfRange:
+		return ErrValidation
+	case StatusTimedOut:
+		return ErrTimeout
+	default:
+		return nil
+	}
+}
+
+// Kind returns the EdgeX error kind matching the status
+func (e *XRTError) Kind() errors.ErrKind {
+	switch e.Unwrap() {
+	case ErrNotFound:
+		return errors.KindEntityDoesNotExist
+	case ErrAlreadyExists:
+		return errors.KindDuplicateName
+	case ErrInUse:
+		return errors.KindStatusConflict
+	case ErrValidation:
+		return errors.KindContractInvalid
+	case ErrTimeout:
+		return KindTimeout
+	default:
+		return errors.KindServerError
+	}
+}
+
+// newXRTError converts a non-zero result status reported by XRT to an EdgeX error wrapping an XRTError
+func newXRTError(status int, message string, op string, requestId string) errors.EdgeX {
+	xrtErr := &XRTError{Status: status, Message: message, Op: op, RequestId: requestId}
+	return errors.NewCommonEdgeX(xrtErr.Kind(), "", xrtErr)
+}
+
+// resultStatus returns the result status code of the XRT reply, or -1 if the reply doesn't carry one
+func resultStatus(replyCodec codec.Codec, reply []byte) int {
+	var statusResponse struct {
+		Result struct {
+			Status *int `json:"status"`
+		} `json:"result"`
+	}
+	if err := replyCodec.Unmarshal(reply, &statusResponse); err != nil || statusResponse.Result.Status == nil {
+		return -1
+	}
+	return *statusResponse.Result.Status
+}
+
+// timeoutError marks an expired context deadline as ErrTimeout while still matching context.DeadlineExceeded
+type timeoutError struct {
+	err error
+}
+
+func (e timeoutError) Error() string {
+	return e.err.Error()
+}
+
+func (e timeoutError) Unwrap() []error {
+	return []error{ErrTimeout, e.err}
+}
+
+// publishError marks a failure of the message bus to publish the request as ErrPublishFailed
+type publishError struct {
+	err error
+}
+
+func (e publishError) Error() string {
+	return e.err.Error()
+}
+
+func (e publishError) Unwrap() []error {
+	return []error{ErrPublishFailed, e.err}
+}
+
+// newPublishError converts an error of the message bus publishing the request to an EdgeX error
+func newPublishError(err error) errors.EdgeX {
+	return errors.NewCommonEdgeX(errors.KindCommunicationError, "failed to send the XRT request", publishError{err: err})
+}
+
+// contextError converts the error of a done context to an EdgeX error wrapping ctx.Err(), so callers can still
+// check it with errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded)
+func contextError(ctx context.Context) errors.EdgeX {
+	err := ctx.Err()
+	if stdErrors.Is(err, context.DeadlineExceeded) {
+		return errors.NewCommonEdgeX(KindTimeout, "context deadline exceeded before the XRT request completed", timeoutError{err: err})
+	}
+	return errors.NewCommonEdgeX(KindCanceled, "context cancelled before the XRT request completed", err)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+const (
+	// DefaultAuditFileMaxSize is the size at which a FileAuditSink rotates its file unless configured otherwise
+	DefaultAuditFileMaxSize int64 = 10 << 20
+	// DefaultAuditFileMaxBackups is the number of rotated files a FileAuditSink keeps unless configured otherwise
+	DefaultAuditFileMaxBackups = 5
+)
+
+// FileAuditSink is an AuditSink appending the records as JSON lines to a file. Once the file would exceed MaxSize it
+// is renamed to <path>.1, shifting older backups to <path>.2 and so on, and the oldest backup beyond MaxBackups is
+// removed.
+type FileAuditSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+var _ AuditSink = (*FileAuditSink)(nil)
+
+// NewFileAuditSink opens, or creates, the audit file at path. maxSize and maxBackups default to
+// DefaultAuditFileMaxSize and DefaultAuditFileMaxBackups when not positive.
+func NewFileAuditSink(path string, maxSize int64, maxBackups int) (*FileAuditSink, errors.EdgeX) {
+	if path == "" {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "path of the audit file is required", nil)
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultAuditFileMaxSize
+	}
+	if maxBackups <= 0 {
+		maxBackups = DefaultAuditFileMaxBackups
+	}
+	sink := &FileAuditSink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := sink.open(); err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return sink, nil
+}
+
+// WriteAudit appends the record to the audit file, rotating it first if the record doesn't fit. If the rotation fails,
+// the record is appended to the current file anyway and the rotation error is returned.
+func (s *FileAuditSink) WriteAudit(record AuditRecord) errors.EdgeX {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the audit record", err)
+	}
+	line = append(line, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("audit file %s is closed", s.path), nil)
+	}
+	var rotateErr errors.EdgeX
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		rotateErr = s.rotate()
+		if s.file == nil {
+			return errors.NewCommonEdgeXWrapper(rotateErr)
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to write to audit file %s", s.path), err)
+	}
+	if rotateErr != nil {
+		return errors.NewCommonEdgeXWrapper(rotateErr)
+	}
+	return nil
+}
+
+// Close closes the audit file, subsequent writes fail
+func (s *FileAuditSink) Close() errors.EdgeX {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to close audit file %s", s.path), err)
+	}
+	return nil
+}
+
+func (s *FileAuditSink) open() errors.EdgeX {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to open audit file %s", s.path), err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to stat audit file %s", s.path), err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file to the first backup and opens a new one. The audit file is reopened even if the
+// rotation fails, so that the records keep being appended to it; s.file is only nil if reopening failed as well.
+func (s *FileAuditSink) rotate() errors.EdgeX {
+	rotateErr := s.shiftFiles()
+	s.file = nil
+	if err := s.open(); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return rotateErr
+}
+
+// shiftFiles closes the current file and renames it and its backups to the next backup path
+func (s *FileAuditSink) shiftFiles() errors.EdgeX {
+	if err := s.file.Close(); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to close audit file %s", s.path), err)
+	}
+	_ = os.Remove(s.backupPath(s.maxBackups))
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to rotate audit file %s", s.backupPath(i)), err)
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to rotate audit file %s", s.path), err)
+	}
+	return nil
+}
+
+func (s *FileAuditSink) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
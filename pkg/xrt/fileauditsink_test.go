@@ -0,0 +1,219 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+// auditRecordLine returns the record of the requestId and the length of its line in the audit file
+func auditRecordLine(t *testing.T, requestId string) (xrt.AuditRecord, int64) {
+	t.Helper()
+	record := xrt.AuditRecord{Op: xrt.OpDeviceAdd, RequestId: requestId, Outcome: xrt.OutcomeOK}
+	line, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("failed to encode the audit record: %v", err)
+	}
+	return record, int64(len(line) + 1)
+}
+
+// readAuditFile returns the records of the audit file at path
+func readAuditFile(t *testing.T, path string) []xrt.AuditRecord {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit file: %v", err)
+	}
+	defer file.Close()
+	var records []xrt.AuditRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record xrt.AuditRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("failed to decode audit record %s: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// auditRequestIds returns the requestIds of the records of the audit file at path
+func auditRequestIds(t *testing.T, path string) []string {
+	t.Helper()
+	var requestIds []string
+	for _, record := range readAuditFile(t, path) {
+		requestIds = append(requestIds, record.RequestId)
+	}
+	return requestIds
+}
+
+func TestFileAuditSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	_, lineSize := auditRecordLine(t, "request-0")
+	// two records fit into a file
+	sink, err := xrt.NewFileAuditSink(path, 2*lineSize, 2)
+	if err != nil {
+		t.Fatalf("NewFileAuditSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	for i := range 7 {
+		record, _ := auditRecordLine(t, fmt.Sprintf("request-%d", i))
+		if err = sink.WriteAudit(record); err != nil {
+			t.Fatalf("WriteAudit %d failed: %v", i, err)
+		}
+	}
+
+	want := map[string][]string{
+		path:        {"request-6"},
+		path + ".1": {"request-4", "request-5"},
+		path + ".2": {"request-2", "request-3"},
+	}
+	for file, requestIds := range want {
+		if got := auditRequestIds(t, file); !slices.Equal(got, requestIds) {
+			t.Errorf("%s holds %v, want %v", filepath.Base(file), got, requestIds)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("backup beyond MaxBackups exists: %v", err)
+	}
+}
+
+func TestFileAuditSinkReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	_, lineSize := auditRecordLine(t, "request-0")
+	sink, err := xrt.NewFileAuditSink(path, lineSize, 1)
+	if err != nil {
+		t.Fatalf("NewFileAuditSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	// a non-empty directory in place of the backup makes the rotation fail
+	if err := os.MkdirAll(filepath.Join(path+".1", "blocked"), 0700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	for i := range 3 {
+		record, _ := auditRecordLine(t, fmt.Sprintf("request-%d", i))
+		err = sink.WriteAudit(record)
+		if i > 0 && err == nil {
+			t.Fatalf("WriteAudit %d succeeded while the rotation can't succeed", i)
+		}
+	}
+	if got := auditRequestIds(t, path); !slices.Equal(got, []string{"request-0", "request-1", "request-2"}) {
+		t.Fatalf("audit file holds %v after failed rotations, want all records", got)
+	}
+
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatalf("failed to remove directory: %v", err)
+	}
+	record, _ := auditRecordLine(t, "request-3")
+	if err = sink.WriteAudit(record); err != nil {
+		t.Fatalf("WriteAudit failed once the rotation is possible again: %v", err)
+	}
+	if got := auditRequestIds(t, path); !slices.Equal(got, []string{"request-3"}) {
+		t.Fatalf("audit file holds %v, want the record written after the rotation", got)
+	}
+	if got := auditRequestIds(t, path+".1"); len(got) != 3 {
+		t.Fatalf("backup holds %v, want the records of the failed rotations", got)
+	}
+}
+
+func TestAuditLogRedaction(t *testing.T) {
+	tests := []struct {
+		name           string
+		redactedFields []string
+		redacted       []string
+		kept           []string
+	}{
+		{"default", nil, []string{"Password", "api_key", "token"}, []string{"Host", "Port"}},
+		{"custom", []string{"host"}, []string{"Host"}, []string{"Password", "api_key", "token", "Port"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := xrttest.NewMessageBus()
+			node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "redact/request", CommandTopic: "redact/command", ReplyTopic: "redact/reply"})
+			node.AddComponent(xrttest.Component{Name: "mqtt-1", Category: "north"})
+			if err := node.Start(); err != nil {
+				t.Fatalf("failed to start fake XRT node: %v", err)
+			}
+			defer node.Stop()
+
+			path := filepath.Join(t.TempDir(), "audit.log")
+			sink, err := xrt.NewFileAuditSink(path, 0, 0)
+			if err != nil {
+				t.Fatalf("NewFileAuditSink failed: %v", err)
+			}
+			defer sink.Close()
+			options := xrt.NewClientOptions(xrt.NewCommandOptions("redact/command", "", nil), nil, nil)
+			options.AuditLog = xrt.NewAuditLog(sink)
+			options.AuditLog.RedactedFields = tt.redactedFields
+			client, err := xrt.NewXrtClient(context.Background(), bus, "redact/request", "redact/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+			if err != nil {
+				t.Fatalf("failed to create xrt client: %v", err)
+			}
+			defer client.Close()
+
+			config := map[string]any{
+				"Host":     "broker",
+				"Password": "secret",
+				"Auth":     map[string]any{"api_key": "key", "Port": 1883.0},
+				"Users":    []any{map[string]any{"token": "abc"}},
+			}
+			if err = client.UpdateComponent(context.Background(), "mqtt-1", config); err != nil {
+				t.Fatalf("UpdateComponent failed: %v", err)
+			}
+			if component, _ := node.Component("mqtt-1"); component.Config["Password"] != "secret" {
+				t.Fatalf("node received the config %v, want the secrets redacted only in the audit record", component.Config)
+			}
+
+			records := readAuditFile(t, path)
+			if len(records) != 1 {
+				t.Fatalf("audit file holds %d records, want 1", len(records))
+			}
+			values := make(map[string]any)
+			collectFields(records[0].Request, values)
+			for _, field := range tt.redacted {
+				if values[field] != "[REDACTED]" {
+					t.Errorf("field %s is %v in the audit record, want it redacted", field, values[field])
+				}
+			}
+			for _, field := range tt.kept {
+				if values[field] == nil || values[field] == "[REDACTED]" {
+					t.Errorf("field %s is %v in the audit record, want it kept", field, values[field])
+				}
+			}
+		})
+	}
+}
+
+// collectFields adds the leaf fields of the value at any depth to fields
+func collectFields(value any, fields map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, fieldValue := range v {
+			switch fieldValue.(type) {
+			case map[string]any, []any:
+				collectFields(fieldValue, fields)
+			default:
+				fields[key] = fieldValue
+			}
+		}
+	case []any:
+		for _, item := range v {
+			collectFields(item, fields)
+		}
+	}
+}
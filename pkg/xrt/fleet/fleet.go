@@ -0,0 +1,274 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package fleet runs the same operation on many XRT nodes, e.g. to push a profile, schedule or Lua script to a fleet
+// of nodes sharing one message bus, and reports the outcome of every node.
+package fleet
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/internal/parallel"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// DefaultParallelism is the number of nodes an operation runs on at the same time unless configured otherwise
+const DefaultParallelism = 8
+
+// ErrSkipped is reported for the nodes an operation didn't run on because it failed on another node first, see
+// StopOnFirstFailure
+var ErrSkipped = stdErrors.New("fleet operation skipped")
+
+// Mode decides how a fleet operation proceeds once it failed on a node
+type Mode int
+
+const (
+	// AllOrReport runs the operation on every node and reports the outcome of each of them
+	AllOrReport Mode = iota
+	// StopOnFirstFailure doesn't start the operation on further nodes once it failed on one. Nodes the operation is
+	// already running on complete, the others are reported with an error matching ErrSkipped.
+	StopOnFirstFailure
+)
+
+// Filter selects the nodes an operation runs on
+type Filter func(nodeID string) bool
+
+// Only selects the given nodes
+func Only(nodeIDs ...string) Filter {
+	return func(nodeID string) bool {
+		return slices.Contains(nodeIDs, nodeID)
+	}
+}
+
+// Online selects the nodes the registry knows as online
+func Online(registry *xrt.NodeRegistry) Filter {
+	return func(nodeID string) bool {
+		node, ok := registry.Node(nodeID)
+		return ok && node.State == xrt.NodeOnline
+	}
+}
+
+// RunOptions provides the config of a fleet operation
+type RunOptions struct {
+	Mode Mode
+	// Filter selects the nodes to run on, nil selects all nodes of the fleet
+	Filter Filter
+	// Parallelism bounds the nodes the operation runs on at the same time, defaults to DefaultParallelism
+	Parallelism int
+}
+
+// Result is the outcome of an operation on a node
+type Result[T any] struct {
+	Node    string
+	Value   T
+	Err     errors.EdgeX
+	Latency time.Duration
+}
+
+// Results are the outcomes of an operation by node id
+type Results[T any] map[string]Result[T]
+
+// Succeeded returns the nodes the operation succeeded on, in order
+func (r Results[T]) Succeeded() []string {
+	return r.nodes(func(result Result[T]) bool { return result.Err == nil })
+}
+
+// Failed returns the nodes the operation failed on, including the skipped ones, in order
+func (r Results[T]) Failed() []string {
+	return r.nodes(func(result Result[T]) bool { return result.Err != nil })
+}
+
+// Err returns nil if the operation succeeded on every node, or an error listing the failed nodes which wraps their
+// errors. Its kind is the one of the first failed node.
+func (r Results[T]) Err() errors.EdgeX {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(failed))
+	for _, node := range failed {
+		errs = append(errs, fmt.Errorf("node %s: %w", node, r[node].Err))
+	}
+	return errors.NewCommonEdgeX(errors.Kind(r[failed[0]].Err),
+		fmt.Sprintf("operation failed on %d of %d nodes: %s", len(failed), len(r), strings.Join(failed, ", ")), stdErrors.Join(errs...))
+}
+
+func (r Results[T]) nodes(match func(result Result[T]) bool) []string {
+	var nodes []string
+	for _, node := range slices.Sorted(maps.Keys(r)) {
+		if match(r[node]) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Fleet runs operations on the EdgeClients of several XRT nodes. Fleet is safe for concurrent use.
+type Fleet struct {
+	mutex   sync.RWMutex
+	clients map[string]interfaces.EdgeClient
+}
+
+// NewFleet creates a Fleet of the given clients by node id
+func NewFleet(clients map[string]interfaces.EdgeClient) *Fleet {
+	return &Fleet{clients: maps.Clone(clients)}
+}
+
+// ForNodes creates a Fleet of node views of the client, see xrt.Client.ForNode
+func ForNodes(client *xrt.Client, nodeIDs ...string) (*Fleet, errors.EdgeX) {
+	f := NewFleet(nil)
+	for _, nodeID := range nodeIDs {
+		if err := f.AddNode(client, nodeID); err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return f, nil
+}
+
+// Add adds or replaces the client of the node
+func (f *Fleet) Add(nodeID string, client interfaces.EdgeClient) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.clients == nil {
+		f.clients = make(map[string]interfaces.EdgeClient)
+	}
+	f.clients[nodeID] = client
+}
+
+// AddNode adds the node view of the client, see xrt.Client.ForNode
+func (f *Fleet) AddNode(client *xrt.Client, nodeID string) errors.EdgeX {
+	view, err := client.ForNode(nodeID)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to add node %s to the fleet", nodeID), err)
+	}
+	f.Add(nodeID, view)
+	return nil
+}
+
+// Remove removes the node from the fleet without closing its client
+func (f *Fleet) Remove(nodeID string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.clients, nodeID)
+}
+
+// Nodes returns the nodes of the fleet in order
+func (f *Fleet) Nodes() []string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return slices.Sorted(maps.Keys(f.clients))
+}
+
+// Client returns the client of the node
+func (f *Fleet) Client(nodeID string) (interfaces.EdgeClient, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	client, ok := f.clients[nodeID]
+	return client, ok
+}
+
+// Run runs the operation on the selected nodes
+func (f *Fleet) Run(ctx context.Context, options RunOptions, operation func(ctx context.Context, nodeID string, client interfaces.EdgeClient) errors.EdgeX) Results[struct{}] {
+	return Collect(ctx, f, options, func(ctx context.Context, nodeID string, client interfaces.EdgeClient) (struct{}, errors.EdgeX) {
+		return struct{}{}, operation(ctx, nodeID, client)
+	})
+}
+
+// Close closes the clients of every node
+func (f *Fleet) Close() errors.EdgeX {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var errs []error
+	for _, nodeID := range slices.Sorted(maps.Keys(f.clients)) {
+		if err := f.clients[nodeID].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", nodeID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewCommonEdgeX(errors.KindServerError, "failed to close the fleet", stdErrors.Join(errs...))
+	}
+	return nil
+}
+
+// Collect runs the operation returning a value on the selected nodes of the fleet, in node order with at most
+// Parallelism nodes at a time
+func Collect[T any](ctx context.Context, f *Fleet, options RunOptions, operation func(ctx context.Context, nodeID string, client interfaces.EdgeClient) (T, errors.EdgeX)) Results[T] {
+	f.mutex.RLock()
+	var nodes []string
+	clients := make(map[string]interfaces.EdgeClient)
+	for _, nodeID := range slices.Sorted(maps.Keys(f.clients)) {
+		if options.Filter == nil || options.Filter(nodeID) {
+			nodes = append(nodes, nodeID)
+			clients[nodeID] = f.clients[nodeID]
+		}
+	}
+	f.mutex.RUnlock()
+
+	parallelism := options.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	// each node writes its own result, the map is built once every node completed
+	nodeResults := make([]Result[T], len(nodes))
+	var failed atomic.Bool
+	parallel.ForEach(len(nodes), parallelism,
+		func(i int) bool {
+			if (failed.Load() && options.Mode == StopOnFirstFailure) || ctx.Err() != nil {
+				nodeResults[i] = skipped[T](ctx, nodes[i])
+				return true
+			}
+			return false
+		},
+		func(i int) {
+			runOn(ctx, &nodeResults[i], nodes[i], clients[nodes[i]], operation)
+			if nodeResults[i].Err != nil {
+				failed.Store(true)
+			}
+		},
+		func(i int, recovered any) {
+			nodeResults[i].Err = errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("fleet operation panicked on node %s: %v", nodes[i], recovered), nil)
+			failed.Store(true)
+		})
+
+	results := make(Results[T], len(nodes))
+	for _, result := range nodeResults {
+		results[result.Node] = result
+	}
+	return results
+}
+
+// runOn runs the operation on the node into result and measures its latency, which is recorded even if the operation
+// panics
+func runOn[T any](ctx context.Context, result *Result[T], nodeID string, client interfaces.EdgeClient, operation func(ctx context.Context, nodeID string, client interfaces.EdgeClient) (T, errors.EdgeX)) {
+	start := time.Now()
+	result.Node = nodeID
+	defer func() {
+		result.Latency = time.Since(start)
+	}()
+	result.Value, result.Err = operation(ctx, nodeID, client)
+}
+
+// skipped returns the result of a node the operation didn't run on. Once ctx is done, the error has the kind of the
+// context errors of the client, like those of xrt.RunBulk: xrt.KindTimeout matching xrt.ErrTimeout for an expired
+// deadline, xrt.KindCanceled otherwise.
+func skipped[T any](ctx context.Context, nodeID string) Result[T] {
+	if err := ctx.Err(); err != nil {
+		message := fmt.Sprintf("fleet operation not run on node %s", nodeID)
+		if stdErrors.Is(err, context.DeadlineExceeded) {
+			return Result[T]{Node: nodeID, Err: errors.NewCommonEdgeX(xrt.KindTimeout, message, stdErrors.Join(ErrSkipped, xrt.ErrTimeout, err))}
+		}
+		return Result[T]{Node: nodeID, Err: errors.NewCommonEdgeX(xrt.KindCanceled, message, stdErrors.Join(ErrSkipped, err))}
+	}
+	return Result[T]{Node: nodeID, Err: errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("fleet operation not run on node %s after an earlier failure", nodeID), ErrSkipped)}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package fleet_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/fleet"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+func TestStopOnFirstFailure(t *testing.T) {
+	f := fleet.NewFleet(map[string]interfaces.EdgeClient{"node-1": nil, "node-2": nil, "node-3": nil, "node-4": nil})
+	options := fleet.RunOptions{Mode: fleet.StopOnFirstFailure, Parallelism: 2}
+	results := f.Run(context.Background(), options, func(ctx context.Context, nodeID string, _ interfaces.EdgeClient) errors.EdgeX {
+		if nodeID == "node-1" {
+			return errors.NewCommonEdgeX(errors.KindServerError, "failed", nil)
+		}
+		// still running while the remaining nodes are skipped
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	if succeeded := results.Succeeded(); !slices.Equal(succeeded, []string{"node-2"}) {
+		t.Fatalf("operation succeeded on %v, want node-2 which was running when node-1 failed", succeeded)
+	}
+	for _, node := range []string{"node-3", "node-4"} {
+		if err := results[node].Err; !stdErrors.Is(err, fleet.ErrSkipped) {
+			t.Fatalf("result of %s is %v, want ErrSkipped", node, err)
+		}
+	}
+}
+
+func TestContextDoneSkipsNodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		kind    errors.ErrKind
+		ctxErr  error
+	}{
+		{"cancelled", 0, xrt.KindCanceled, context.Canceled},
+		{"deadline exceeded", 20 * time.Millisecond, xrt.KindTimeout, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.timeout > 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), tt.timeout)
+			}
+			defer cancel()
+			f := fleet.NewFleet(map[string]interfaces.EdgeClient{"node-1": nil, "node-2": nil, "node-3": nil, "node-4": nil})
+			results := f.Run(ctx, fleet.RunOptions{Parallelism: 1}, func(ctx context.Context, nodeID string, _ interfaces.EdgeClient) errors.EdgeX {
+				if nodeID == "node-2" {
+					// ctx is done while the operation runs on node-2
+					if tt.timeout == 0 {
+						cancel()
+					}
+					<-ctx.Done()
+				}
+				return nil
+			})
+
+			if succeeded := results.Succeeded(); !slices.Equal(succeeded, []string{"node-1", "node-2"}) {
+				t.Fatalf("operation succeeded on %v, want node-1 and node-2 which ran before ctx was done", succeeded)
+			}
+			for _, node := range []string{"node-3", "node-4"} {
+				err := results[node].Err
+				if !stdErrors.Is(err, fleet.ErrSkipped) || !stdErrors.Is(err, tt.ctxErr) || errors.Kind(err) != tt.kind {
+					t.Fatalf("result of %s is %v of kind %s, want ErrSkipped and %v of kind %s", node, err, errors.Kind(err), tt.ctxErr, tt.kind)
+				}
+			}
+			if timedOut := stdErrors.Is(results["node-3"].Err, xrt.ErrTimeout); timedOut != (tt.timeout > 0) {
+				t.Fatalf("result of node-3 matches ErrTimeout %v, want %v", timedOut, tt.timeout > 0)
+			}
+		})
+	}
+}
+
+func TestPanicFailsNode(t *testing.T) {
+	f := fleet.NewFleet(map[string]interfaces.EdgeClient{"node-1": nil, "node-2": nil})
+	results := f.Run(context.Background(), fleet.RunOptions{}, func(ctx context.Context, nodeID string, _ interfaces.EdgeClient) errors.EdgeX {
+		if nodeID == "node-1" {
+			panic("failed")
+		}
+		return nil
+	})
+
+	if failed := results.Failed(); !slices.Equal(failed, []string{"node-1"}) {
+		t.Fatalf("operation failed on %v, want node-1", failed)
+	}
+	if result := results["node-1"]; result.Node != "node-1" || errors.Kind(result.Err) != errors.KindServerError {
+		t.Fatalf("result of the panicking node is %+v", result)
+	}
+}
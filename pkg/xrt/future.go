@@ -0,0 +1,96 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// Future is the pending outcome of an asynchronous XRT request
+type Future[T any] struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+	result T
+	err    errors.EdgeX
+}
+
+// Async runs the call in the background and returns a Future of its outcome. The call gets a context derived from
+// ctx which is cancelled by Future.Cancel, so any EdgeClient method can be made asynchronous:
+//
+//	future := xrt.Async(ctx, func(ctx context.Context) ([]string, errors.EdgeX) { return client.AllDevices(ctx) })
+func Async[T any](ctx context.Context, call func(ctx context.Context) (T, errors.EdgeX)) *Future[T] {
+	callCtx, cancel := context.WithCancel(ctx)
+	f := &Future[T]{done: make(chan struct{}), cancel: cancel}
+	go func() {
+		defer close(f.done)
+		defer cancel()
+		f.result, f.err = call(callCtx)
+	}()
+	return f
+}
+
+// asyncErr runs a call without a result in the background
+func asyncErr(ctx context.Context, call func(ctx context.Context) errors.EdgeX) *Future[struct{}] {
+	return Async(ctx, func(ctx context.Context) (struct{}, errors.EdgeX) {
+		return struct{}{}, call(ctx)
+	})
+}
+
+// Done returns a channel which is closed once the request has completed, failed or been cancelled
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the request completes and returns its outcome. If ctx is done first, Wait returns the context
+// error while the request carries on; use Cancel to abandon the request itself.
+func (f *Future[T]) Wait(ctx context.Context) (T, errors.EdgeX) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, contextError(ctx)
+	}
+}
+
+// Cancel abandons the request, Wait then returns an error of KindCanceled unless the reply has already arrived
+func (f *Future[T]) Cancel() {
+	f.cancel()
+}
+
+// SendAsync sends an XRT request built by the xrtmodels constructors and decodes the whole reply into R, such as
+// xrtmodels.CommonResponse. An error result reported by XRT fails the Future with an XRTError.
+func SendAsync[R any](ctx context.Context, c *Client, op string, request any) *Future[R] {
+	return Async(ctx, func(ctx context.Context) (R, errors.EdgeX) {
+		var response R
+		requestId, err := requestIdOf(request)
+		if err != nil {
+			return response, errors.NewCommonEdgeXWrapper(err)
+		}
+		err = c.sendXrtRequest(ctx, op, requestId, request, &response)
+		if err != nil {
+			return response, errors.NewCommonEdgeXWrapper(err)
+		}
+		return response, nil
+	})
+}
+
+// requestIdOf returns the RequestId of the xrtmodels request
+func requestIdOf(request any) (string, errors.EdgeX) {
+	value := reflect.ValueOf(request)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unexpected XRT request type %T", request), nil)
+	}
+	field := value.FieldByName("RequestId")
+	if !field.IsValid() || field.Kind() != reflect.String || field.String() == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("XRT request type %T has no RequestId", request), nil)
+	}
+	return field.String(), nil
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+func TestAsync(t *testing.T) {
+	failure := errors.NewCommonEdgeX(errors.KindServerError, "failed", nil)
+	tests := []struct {
+		name   string
+		result int
+		err    errors.EdgeX
+	}{
+		{"result", 42, nil},
+		{"error", 0, failure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			future := xrt.Async(context.Background(), func(context.Context) (int, errors.EdgeX) { return tt.result, tt.err })
+			select {
+			case <-future.Done():
+			case <-time.After(time.Second):
+				t.Fatal("Done wasn't closed once the call returned")
+			}
+			result, err := future.Wait(context.Background())
+			if result != tt.result || err != tt.err {
+				t.Fatalf("Wait returned %d, %v, want %d, %v", result, err, tt.result, tt.err)
+			}
+		})
+	}
+}
+
+func TestAsyncWaitContext(t *testing.T) {
+	release := make(chan struct{})
+	future := xrt.Async(context.Background(), func(context.Context) (int, errors.EdgeX) {
+		<-release
+		return 42, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := future.Wait(ctx); errors.Kind(err) != xrt.KindTimeout || !stdErrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait returned %v of kind %s, want the expired deadline", err, errors.Kind(err))
+	}
+
+	// the request carries on when Wait gives up
+	close(release)
+	if result, err := future.Wait(context.Background()); result != 42 || err != nil {
+		t.Fatalf("Wait returned %d, %v after the call returned, want its result", result, err)
+	}
+}
+
+func TestAsyncCancel(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "async/request", ReplyTopic: "async/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	client, err := xrt.NewXrtClient(context.Background(), bus, "async/request", "async/reply", time.Second, logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	xrtClient := client.(*xrt.Client)
+	node.SetFault(xrttest.OpDeviceAdd, xrttest.Fault{Drop: true})
+
+	future := xrtClient.AddDeviceAsync(context.Background(), dtos.Device{Name: "device", ProfileName: "profile"})
+	time.AfterFunc(20*time.Millisecond, future.Cancel)
+	if _, err = future.Wait(context.Background()); errors.Kind(err) != xrt.KindCanceled || !stdErrors.Is(err, context.Canceled) {
+		t.Fatalf("Wait returned %v of kind %s after Cancel, want the cancellation", err, errors.Kind(err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	future = xrtClient.AddDeviceAsync(ctx, dtos.Device{Name: "device", ProfileName: "profile"})
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err = future.Wait(context.Background()); errors.Kind(err) != xrt.KindCanceled {
+		t.Fatalf("Wait returned %v of kind %s once ctx was cancelled, want the cancellation", err, errors.Kind(err))
+	}
+}
+
+func TestAddDeviceAsync(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "adddevice/request", ReplyTopic: "adddevice/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	client, err := xrt.NewXrtClient(context.Background(), bus, "adddevice/request", "adddevice/reply", 100*time.Millisecond, logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	xrtClient := client.(*xrt.Client)
+
+	ctx := context.Background()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	device := dtos.Device{Name: "device", ProfileName: "profile"}
+	if _, err = xrtClient.AddDeviceAsync(ctx, device).Wait(ctx); err != nil {
+		t.Fatalf("AddDeviceAsync failed: %v", err)
+	}
+	if !slices.Equal(node.DeviceNames(), []string{"device"}) {
+		t.Fatalf("node has devices %v, want [device]", node.DeviceNames())
+	}
+	if _, err = xrtClient.AddDeviceAsync(ctx, device).Wait(ctx); !stdErrors.Is(err, xrt.ErrAlreadyExists) {
+		t.Fatalf("AddDeviceAsync of an existing device returned %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestSendAsync(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "sendasync/request", ReplyTopic: "sendasync/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	client, err := xrt.NewXrtClient(context.Background(), bus, "sendasync/request", "sendasync/reply", 100*time.Millisecond, logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	xrtClient := client.(*xrt.Client)
+
+	ctx := context.Background()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err = client.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+
+	request := xrtmodels.NewAllDevicesRequest("test")
+	for _, r := range []any{request, &request} {
+		response, err := xrt.SendAsync[xrtmodels.MultiDevicesResponse](ctx, xrtClient, xrt.OpDeviceList, r).Wait(ctx)
+		if err != nil || !slices.Equal(response.Result.Devices, []string{"device"}) {
+			t.Fatalf("SendAsync of a %T returned %+v, %v", r, response, err)
+		}
+	}
+	requests := node.Requests()
+	if last := requests[len(requests)-1]; last.RequestId != request.RequestId {
+		t.Fatalf("node received the request %s, want the RequestId %s of the xrtmodels request", last.RequestId, request.RequestId)
+	}
+
+	node.SetFault(xrttest.OpDeviceGet, xrttest.Fault{Status: xrttest.StatusNotFound, Message: "gone", Count: 1})
+	_, err = xrt.SendAsync[xrtmodels.DeviceResponse](ctx, xrtClient, xrt.OpDeviceGet, xrtmodels.NewDeviceGetRequest("device", "test")).Wait(ctx)
+	var xrtErr *xrt.XRTError
+	if !stdErrors.Is(err, xrt.ErrNotFound) || !stdErrors.As(err, &xrtErr) || xrtErr.Message != "gone" {
+		t.Fatalf("SendAsync returned %v, want the error result of the node", err)
+	}
+
+	received := len(node.Requests())
+	for _, invalid := range []any{"device:list", struct{ Op string }{xrt.OpDeviceList}, xrtmodels.BaseRequest{Op: xrt.OpDeviceList}} {
+		_, err = xrt.SendAsync[xrtmodels.CommonResponse](ctx, xrtClient, xrt.OpDeviceList, invalid).Wait(ctx)
+		if errors.Kind(err) != errors.KindContractInvalid {
+			t.Fatalf("SendAsync of %#v returned %v of kind %s, want KindContractInvalid", invalid, err, errors.Kind(err))
+		}
+	}
+	if len(node.Requests()) != received {
+		t.Fatal("requests without a RequestId were sent")
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+const (
+	defaultReadyInitialBackoff = 100 * time.Millisecond
+	defaultReadyMaxBackoff     = 5 * time.Second
+)
+
+// ReadinessOptions provides the config of Ping and WaitReady
+type ReadinessOptions struct {
+	// NodeRegistry, if set, makes Ping require an online node: the addressed node for node views, any node otherwise
+	NodeRegistry *NodeRegistry
+	// Backoff sets the delays between the pings of WaitReady, its MaxAttempts is ignored. Defaults to an exponential
+	// backoff from 100ms up to 5s.
+	Backoff *RetryPolicy
+}
+
+func NewReadinessOptions(nodeRegistry *NodeRegistry, backoff *RetryPolicy) *ReadinessOptions {
+	return &ReadinessOptions{
+		NodeRegistry: nodeRegistry,
+		Backoff:      backoff,
+	}
+}
+
+// healthState tracks the outcome of the requests sent by a client and its node views
+type healthState struct {
+	mutex         sync.Mutex
+	lastRoundTrip time.Time
+	lastError     string
+	lastErrorTime time.Time
+}
+
+// observe records the outcome of a request, replied reports whether XRT replied even if with an error result
+func (h *healthState) observe(replied bool, err errors.EdgeX) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if replied {
+		h.lastRoundTrip = time.Now()
+		return
+	}
+	if err != nil {
+		h.lastError = err.Error()
+		h.lastErrorTime = time.Now()
+	}
+}
+
+// observeHealth records the outcome of a request in the health state of the client. Requests of a client in dry-run
+// mode aren't recorded, as their synthetic replies say nothing about XRT.
+func (c *Client) observeHealth(replied bool, err errors.EdgeX) {
+	if c.dryRunEnabled() {
+		return
+	}
+	c.health.observe(replied, err)
+}
+
+// Ping checks that the reply subscription is active, that the node registry of the ReadinessOptions, if any, knows
+// an online node, and that XRT replies to a device list request. Errors of unready clients match ErrNotReady.
+func (c *Client) Ping(ctx context.Context) errors.EdgeX {
+	if c.replyTopicManager == nil || !c.replyTopicManager.Active() {
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("subscription to reply topic %s is not active", c.replyTopic), ErrNotReady)
+	}
+	if err := c.checkNodeOnline(); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if c.dryRunEnabled() {
+		// XRT doesn't reply in dry-run mode
+		return nil
+	}
+
+	request := xrtmodels.NewAllDevicesRequest(clientName)
+	var response xrtmodels.MultiDevicesResponse
+	err := c.sendXrtRequest(ctx, OpDeviceList, request.RequestId, request, &response)
+	var xrtErr *XRTError
+	if err != nil && !stdErrors.As(err, &xrtErr) {
+		return errors.NewCommonEdgeX(errors.Kind(err), "failed to ping XRT", err)
+	}
+	return nil
+}
+
+// WaitReady pings XRT until the ping succeeds, backing off between the attempts as configured in the
+// ReadinessOptions. It returns the last ping error once ctx is done.
+func (c *Client) WaitReady(ctx context.Context) errors.EdgeX {
+	backoff := NewRetryPolicy(0, defaultReadyInitialBackoff, defaultReadyMaxBackoff)
+	if c.clientOptions != nil && c.clientOptions.Readiness != nil && c.clientOptions.Readiness.Backoff != nil {
+		backoff = c.clientOptions.Readiness.Backoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return errors.NewCommonEdgeX(errors.Kind(err), "gave up waiting for XRT to be ready", err)
+		}
+		delay := backoff.backoff(attempt)
+		c.lc.Debugf("XRT not ready on attempt %d, retrying in %v: %v", attempt, delay, err)
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			return errors.NewCommonEdgeX(errors.Kind(waitErr), fmt.Sprintf("gave up waiting for XRT to be ready, last error: %v", err), waitErr)
+		}
+	}
+}
+
+// Health returns a snapshot of the reply subscription and of the outcome of the requests sent so far
+func (c *Client) Health() interfaces.Health {
+	c.health.mutex.Lock()
+	defer c.health.mutex.Unlock()
+	return interfaces.Health{
+		Subscribed:    c.replyTopicManager != nil && c.replyTopicManager.Active(),
+		LastRoundTrip: c.health.lastRoundTrip,
+		LastError:     c.health.lastError,
+		LastErrorTime: c.health.lastErrorTime,
+	}
+}
+
+// checkNodeOnline requires an online node in the node registry of the ReadinessOptions, if any
+func (c *Client) checkNodeOnline() errors.EdgeX {
+	if c.clientOptions == nil || c.clientOptions.Readiness == nil || c.clientOptions.Readiness.NodeRegistry == nil {
+		return nil
+	}
+	registry := c.clientOptions.Readiness.NodeRegistry
+	if c.nodeID == "" {
+		if len(registry.Online()) == 0 {
+			return errors.NewCommonEdgeX(errors.KindServiceUnavailable, "no XRT node has reported online status", ErrNotReady)
+		}
+		return nil
+	}
+	node, ok := registry.Node(c.nodeID)
+	if !ok || node.State != NodeOnline {
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("XRT node %s has not reported online status", c.nodeID), ErrNotReady)
+	}
+	return nil
+}
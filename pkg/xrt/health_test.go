@@ -0,0 +1,154 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+func TestPingNotReady(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "ping/request", ReplyTopic: "ping/reply", StatusTopic: "ping/status", NodeID: "node-1"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	registry := xrt.NewNodeRegistry(time.Hour, nil)
+	if err := registry.Start(bus, "ping/status", logger.NewMockClient()); err != nil {
+		t.Fatalf("failed to start node registry: %v", err)
+	}
+	defer registry.Stop()
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Readiness = xrt.NewReadinessOptions(registry, nil)
+	client, err := xrt.NewXrtClient(context.Background(), bus, "ping/request", "ping/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	xrtClient := client.(*xrt.Client)
+
+	ctx := context.Background()
+	if err = xrtClient.Ping(ctx); !stdErrors.Is(err, xrt.ErrNotReady) {
+		t.Fatalf("Ping returned %v without an online node, want ErrNotReady", err)
+	}
+	if len(node.Requests()) != 0 {
+		t.Fatal("Ping sent a request without an online node")
+	}
+
+	if err = node.PublishStatus("online"); err != nil {
+		t.Fatalf("PublishStatus failed: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(registry.Online()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err = xrtClient.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed with an online node: %v", err)
+	}
+
+	_ = client.Close()
+	if err = xrtClient.Ping(ctx); !stdErrors.Is(err, xrt.ErrNotReady) {
+		t.Fatalf("Ping returned %v without an active reply subscription, want ErrNotReady", err)
+	}
+	if xrtClient.Health().Subscribed {
+		t.Fatal("Health reports the reply subscription of a closed client as active")
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "waitready/request", ReplyTopic: "waitready/reply"})
+	defer node.Stop()
+
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Readiness = xrt.NewReadinessOptions(nil, xrt.NewRetryPolicy(0, 10*time.Millisecond, 20*time.Millisecond))
+	client, err := xrt.NewXrtClient(context.Background(), bus, "waitready/request", "waitready/reply", 20*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	xrtClient := client.(*xrt.Client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err = xrtClient.WaitReady(ctx); !stdErrors.Is(err, xrt.ErrTimeout) {
+		t.Fatalf("WaitReady returned %v without a node, want the timeout of the last ping", err)
+	}
+
+	// the node comes up while WaitReady backs off
+	started := make(chan error, 1)
+	time.AfterFunc(100*time.Millisecond, func() { started <- node.Start() })
+	start := time.Now()
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err = xrtClient.WaitReady(ctx); err != nil {
+		t.Fatalf("WaitReady failed once the node came up: %v", err)
+	}
+	if startErr := <-started; startErr != nil {
+		t.Fatalf("failed to start fake XRT node: %v", startErr)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("WaitReady returned after %v, before the node came up", elapsed)
+	}
+	if requests := node.Requests(); len(requests) != 1 || requests[0].Op != xrttest.OpDeviceList {
+		t.Fatalf("node received %+v, want the single ping answered", requests)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "health/request", ReplyTopic: "health/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+	client, err := xrt.NewXrtClient(context.Background(), bus, "health/request", "health/reply", 50*time.Millisecond, logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	xrtClient := client.(*xrt.Client)
+
+	health := xrtClient.Health()
+	if !health.Subscribed || !health.LastRoundTrip.IsZero() || health.LastError != "" {
+		t.Fatalf("Health of a new client is %+v, want subscribed without round trips or errors", health)
+	}
+
+	ctx := context.Background()
+	if _, err = client.AllDevices(ctx); err != nil {
+		t.Fatalf("AllDevices failed: %v", err)
+	}
+	health = xrtClient.Health()
+	if health.LastRoundTrip.IsZero() || health.LastError != "" {
+		t.Fatalf("Health is %+v after a reply, want the round trip recorded", health)
+	}
+
+	// error results are round trips too
+	firstRoundTrip := health.LastRoundTrip
+	if _, err = client.DeviceByName(ctx, "missing"); !stdErrors.Is(err, xrt.ErrNotFound) {
+		t.Fatalf("DeviceByName returned %v, want ErrNotFound", err)
+	}
+	health = xrtClient.Health()
+	if !health.LastRoundTrip.After(firstRoundTrip) || health.LastError != "" {
+		t.Fatalf("Health is %+v after an error result, want the round trip recorded", health)
+	}
+
+	lastRoundTrip := health.LastRoundTrip
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true, Count: 1})
+	if _, err = client.AllDevices(ctx); !stdErrors.Is(err, xrt.ErrTimeout) {
+		t.Fatalf("AllDevices returned %v, want ErrTimeout", err)
+	}
+	health = xrtClient.Health()
+	if !health.LastRoundTrip.Equal(lastRoundTrip) || !strings.Contains(health.LastError, "timed out") || health.LastErrorTime.IsZero() {
+		t.Fatalf("Health is %+v after a timeout, want the error recorded and the round trip kept", health)
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"bufio"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/topicmgr"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram buckets of InMemoryMetrics
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond,
+	250 * time.Millisecond, 500 * time.Millisecond, time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// InMemoryMetrics is a MetricsRecorder and topicmgr.Observer keeping counters and latency histograms in memory.
+// It serves them, together with the gauges of topicmgr.TmPool, in the Prometheus text format. As the Metrics of a
+// client, it also observes the topic subscriptions of the client:
+//
+//	metrics := xrt.NewInMemoryMetrics(nil)
+//	options.Metrics = metrics
+//	http.Handle("/metrics", metrics)
+type InMemoryMetrics struct {
+	buckets []time.Duration
+
+	mutex  sync.Mutex
+	ops    map[string]*opMetrics
+	topics map[string]*topicMetrics
+}
+
+// OpMetrics is a snapshot of the measurements of an op
+type OpMetrics struct {
+	Requests uint64
+	Timeouts uint64
+	// Errors counts the failed requests by EdgeX error kind
+	Errors map[errors.ErrKind]uint64
+	// Buckets counts the requests whose latency is within the upper bound of the bucket of the same index
+	Buckets      []uint64
+	LatencySum   time.Duration
+	BucketBounds []time.Duration
+}
+
+// TopicMetrics is a snapshot of the events observed on a subscribed topic
+type TopicMetrics struct {
+	RepliesUnmatched uint64
+	RepliesDropped   uint64
+	HandlerPanics    uint64
+}
+
+type opMetrics struct {
+	requests   uint64
+	timeouts   uint64
+	errors     map[errors.ErrKind]uint64
+	buckets    []uint64
+	latencySum time.Duration
+}
+
+type topicMetrics struct {
+	repliesUnmatched uint64
+	repliesDropped   uint64
+	handlerPanics    uint64
+}
+
+var (
+	_ MetricsRecorder   = (*InMemoryMetrics)(nil)
+	_ topicmgr.Observer = (*InMemoryMetrics)(nil)
+	_ http.Handler      = (*InMemoryMetrics)(nil)
+)
+
+// NewInMemoryMetrics creates an InMemoryMetrics with the given latency bucket bounds, or DefaultLatencyBuckets if nil
+func NewInMemoryMetrics(buckets []time.Duration) *InMemoryMetrics {
+	if buckets == nil {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i] < buckets[j]
+	})
+	return &InMemoryMetrics{
+		buckets: buckets,
+		ops:     make(map[string]*opMetrics),
+		topics:  make(map[string]*topicMetrics),
+	}
+}
+
+func (m *InMemoryMetrics) ObserveRequest(op string, duration time.Duration, err errors.EdgeX) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	metrics, ok := m.ops[op]
+	if !ok {
+		metrics = &opMetrics{errors: make(map[errors.ErrKind]uint64), buckets: make([]uint64, len(m.buckets))}
+		m.ops[op] = metrics
+	}
+	metrics.requests++
+	metrics.latencySum += duration
+	for i, bound := range m.buckets {
+		if duration <= bound {
+			metrics.buckets[i]++
+		}
+	}
+	if err != nil {
+		metrics.errors[errors.Kind(err)]++
+		if stdErrors.Is(err, ErrTimeout) {
+			metrics.timeouts++
+		}
+	}
+}
+
+func (m *InMemoryMetrics) ReplyUnmatched(topic string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.topic(topic).repliesUnmatched++
+}
+
+func (m *InMemoryMetrics) ReplyDropped(topic string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.topic(topic).repliesDropped++
+}
+
+func (m *InMemoryMetrics) HandlerPanicked(topic string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.topic(topic).handlerPanics++
+}
+
+// Op returns a snapshot of the measurements of the op
+func (m *InMemoryMetrics) Op(op string) OpMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	snapshot := OpMetrics{
+		Errors:       make(map[errors.ErrKind]uint64),
+		Buckets:      make([]uint64, len(m.buckets)),
+		BucketBounds: append([]time.Duration(nil), m.buckets...),
+	}
+	metrics, ok := m.ops[op]
+	if !ok {
+		return snapshot
+	}
+	snapshot.Requests = metrics.requests
+	snapshot.Timeouts = metrics.timeouts
+	snapshot.LatencySum = metrics.latencySum
+	copy(snapshot.Buckets, metrics.buckets)
+	for kind, count := range metrics.errors {
+		snapshot.Errors[kind] = count
+	}
+	return snapshot
+}
+
+// Topic returns a snapshot of the events observed on the topic
+func (m *InMemoryMetrics) Topic(topic string) TopicMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	metrics, ok := m.topics[topic]
+	if !ok {
+		return TopicMetrics{}
+	}
+	return TopicMetrics{
+		RepliesUnmatched: metrics.repliesUnmatched,
+		RepliesDropped:   metrics.repliesDropped,
+		HandlerPanics:    metrics.handlerPanics,
+	}
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format
+func (m *InMemoryMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := m.WritePrometheus(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// WritePrometheus writes the metrics and the gauges of topicmgr.TmPool in the Prometheus text format
+func (m *InMemoryMetrics) WritePrometheus(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	m.mutex.Lock()
+	m.writeOps(buf)
+	m.writeTopics(buf)
+	m.mutex.Unlock()
+	writePoolStats(buf, topicmgr.TmPool.Stats())
+	return buf.Flush()
+}
+
+func (m *InMemoryMetrics) writeOps(w io.Writer) {
+	ops := sortedKeys(m.ops)
+
+	writeHeader(w, "xrt_client_requests_total", "counter", "XRT request attempts by op.")
+	for _, op := range ops {
+		writeSample(w, "xrt_client_requests_total", labels("op", op), strconv.FormatUint(m.ops[op].requests, 10))
+	}
+	writeHeader(w, "xrt_client_request_errors_total", "counter", "Failed XRT request attempts by op and EdgeX error kind.")
+	for _, op := range ops {
+		kinds := make([]string, 0, len(m.ops[op].errors))
+		for kind := range m.ops[op].errors {
+			kinds = append(kinds, string(kind))
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			count := m.ops[op].errors[errors.ErrKind(kind)]
+			writeSample(w, "xrt_client_request_errors_total", labels("op", op, "kind", kind), strconv.FormatUint(count, 10))
+		}
+	}
+	writeHeader(w, "xrt_client_request_timeouts_total", "counter", "XRT request attempts which timed out by op.")
+	for _, op := range ops {
+		writeSample(w, "xrt_client_request_timeouts_total", labels("op", op), strconv.FormatUint(m.ops[op].timeouts, 10))
+	}
+	writeHeader(w, "xrt_client_request_duration_seconds", "histogram", "Latency of XRT request attempts by op.")
+	for _, op := range ops {
+		metrics := m.ops[op]
+		for i, bound := range m.buckets {
+			writeSample(w, "xrt_client_request_duration_seconds_bucket", labels("op", op, "le", formatSeconds(bound)), strconv.FormatUint(metrics.buckets[i], 10))
+		}
+		writeSample(w, "xrt_client_request_duration_seconds_bucket", labels("op", op, "le", "+Inf"), strconv.FormatUint(metrics.requests, 10))
+		writeSample(w, "xrt_client_request_duration_seconds_sum", labels("op", op), formatSeconds(metrics.latencySum))
+		writeSample(w, "xrt_client_request_duration_seconds_count", labels("op", op), strconv.FormatUint(metrics.requests, 10))
+	}
+}
+
+func (m *InMemoryMetrics) writeTopics(w io.Writer) {
+	topics := sortedKeys(m.topics)
+
+	writeHeader(w, "xrt_client_replies_unmatched_total", "counter", "XRT replies without a waiting request, usually late replies, by topic.")
+	for _, topic := range topics {
+		writeSample(w, "xrt_client_replies_unmatched_total", labels("topic", topic), strconv.FormatUint(m.topics[topic].repliesUnmatched, 10))
+	}
+	writeHeader(w, "xrt_client_replies_dropped_total", "counter", "XRT replies dropped because the waiting request wasn't ready, by topic.")
+	for _, topic := range topics {
+		writeSample(w, "xrt_client_replies_dropped_total", labels("topic", topic), strconv.FormatUint(m.topics[topic].repliesDropped, 10))
+	}
+	writeHeader(w, "xrt_client_handler_panics_total", "counter", "Panics of message handlers by topic.")
+	for _, topic := range topics {
+		writeSample(w, "xrt_client_handler_panics_total", labels("topic", topic), strconv.FormatUint(m.topics[topic].handlerPanics, 10))
+	}
+}
+
+func writePoolStats(w io.Writer, stats []topicmgr.ManagerStats) {
+	managers := map[string]int{topicmgr.ManagerTypeReply: 0, topicmgr.ManagerTypeDispatcher: 0}
+	for _, manager := range stats {
+		managers[manager.Type]++
+	}
+	writeHeader(w, "xrt_client_topic_managers", "gauge", "Topic managers held by the pool by type.")
+	for _, managerType := range sortedKeys(managers) {
+		writeSample(w, "xrt_client_topic_managers", labels("type", managerType), strconv.Itoa(managers[managerType]))
+	}
+	writeHeader(w, "xrt_client_topic_manager_refs", "gauge", "Clients sharing the topic manager of a topic.")
+	for _, manager := range stats {
+		writeSample(w, "xrt_client_topic_manager_refs", labels("topic", manager.Topic, "type", manager.Type), strconv.Itoa(manager.RefCount))
+	}
+	writeHeader(w, "xrt_client_pending_requests", "gauge", "Requests awaiting their reply by reply topic.")
+	for _, manager := range stats {
+		if manager.Type == topicmgr.ManagerTypeReply {
+			writeSample(w, "xrt_client_pending_requests", labels("topic", manager.Topic), strconv.Itoa(manager.PendingRequests))
+		}
+	}
+}
+
+func (m *InMemoryMetrics) topic(topic string) *topicMetrics {
+	metrics, ok := m.topics[topic]
+	if !ok {
+		metrics = &topicMetrics{}
+		m.topics[topic] = metrics
+	}
+	return metrics
+}
+
+func writeHeader(w io.Writer, name string, metricType string, help string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func writeSample(w io.Writer, name string, labels string, value string) {
+	_, _ = fmt.Fprintf(w, "%s{%s} %s\n", name, labels, value)
+}
+
+// labels formats the label name and value pairs, escaping the values as required by the Prometheus text format
+func labels(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(pairs[i+1]))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+func TestMetricsObserveReplyTopic(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "metrics/request", ReplyTopic: "metrics/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	metrics := xrt.NewInMemoryMetrics(nil)
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Metrics = metrics
+	// both clients share the subscription of the reply topic and register the same observer with it
+	client, err := xrt.NewXrtClient(context.Background(), bus, "metrics/request", "metrics/reply", 50*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	other, err := xrt.NewXrtClient(context.Background(), bus, "metrics/request", "metrics/reply", 50*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer other.Close()
+
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Delay: 100 * time.Millisecond, Count: 1})
+	if _, err = client.AllDevices(context.Background()); err == nil {
+		t.Fatal("AllDevices succeeded although the reply was late")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for metrics.Topic("metrics/reply").RepliesUnmatched == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if unmatched := metrics.Topic("metrics/reply").RepliesUnmatched; unmatched != 1 {
+		t.Fatalf("observed %d unmatched replies, want the late reply once", unmatched)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// Call describes a single XRT request/reply exchange as seen by interceptors
+type Call struct {
+	// Op is the XRT operation, e.g. OpDeviceAdd
+	Op string
+	// Topic is the topic the request is published to
+	Topic string
+	// RequestId identifies the request and its replies, it must match the request_id within Request
+	RequestId string
+	// Request is the marshalled request, interceptors may replace it before calling next
+	Request []byte
+	// Timeout is the response timeout, or the subscribe timeout if MultiReply is set
+	Timeout time.Duration
+	// MultiReply is set for requests answered by several XRT nodes, such as component:discover
+	MultiReply bool
+}
+
+// Invoker publishes the request of the call and returns the raw replies. Single-reply calls return exactly one
+// reply; multi-reply calls return every reply received within the subscribe timeout once it has elapsed, while the
+// replies themselves are streamed to the caller as they arrive.
+type Invoker func(ctx context.Context, call *Call) ([][]byte, errors.EdgeX)
+
+// Interceptor wraps the exchange of every XRT request. It may inspect or modify the call and the replies, wrap next
+// with its own behavior, or short-circuit the request by returning without calling next.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) ([][]byte, errors.EdgeX)
+
+// chainInterceptors builds an Invoker which runs the interceptors in order around the terminal invoker, so the
+// first interceptor is the outermost one
+func chainInterceptors(interceptors []Interceptor, terminal Invoker) Invoker {
+	invoker := terminal
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, call *Call) ([][]byte, errors.EdgeX) {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
+
+// invoke runs the call through the interceptors registered in ClientOptions, the version adapters of the call, if
+// any, and then the terminal invoker
+func (c *Client) invoke(ctx context.Context, call *Call, terminal Invoker) ([][]byte, errors.EdgeX) {
+	var interceptors []Interceptor
+	if c.clientOptions != nil {
+		interceptors = slices.Clone(c.clientOptions.Interceptors)
+	}
+	interceptors = append(interceptors, c.adapters(call.Op)...)
+	if len(interceptors) == 0 {
+		return terminal(ctx, call)
+	}
+	return chainInterceptors(interceptors, terminal)(ctx, call)
+}
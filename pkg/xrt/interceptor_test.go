@@ -0,0 +1,178 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+func TestInterceptorOrder(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "interceptor/request", ReplyTopic: "interceptor/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	var mutex sync.Mutex
+	var calls []string
+	record := func(name string) xrt.Interceptor {
+		return func(ctx context.Context, call *xrt.Call, next xrt.Invoker) ([][]byte, errors.EdgeX) {
+			mutex.Lock()
+			calls = append(calls, name+" before "+call.Op)
+			mutex.Unlock()
+			replies, err := next(ctx, call)
+			mutex.Lock()
+			calls = append(calls, name+" after "+call.Op)
+			mutex.Unlock()
+			return replies, err
+		}
+	}
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Interceptors = []xrt.Interceptor{record("first"), record("second")}
+	client, err := xrt.NewXrtClient(context.Background(), bus, "interceptor/request", "interceptor/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err = client.AllDevices(context.Background()); err != nil {
+		t.Fatalf("AllDevices failed: %v", err)
+	}
+	want := []string{
+		"first before " + xrt.OpDeviceList,
+		"second before " + xrt.OpDeviceList,
+		"second after " + xrt.OpDeviceList,
+		"first after " + xrt.OpDeviceList,
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if !slices.Equal(calls, want) {
+		t.Fatalf("interceptors ran as %v, want %v", calls, want)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "shortcircuit/request", ReplyTopic: "shortcircuit/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	nextCalled := false
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Interceptors = []xrt.Interceptor{
+		func(_ context.Context, call *xrt.Call, _ xrt.Invoker) ([][]byte, errors.EdgeX) {
+			reply, err := json.Marshal(map[string]any{
+				"request_id": call.RequestId,
+				"result":     map[string]any{"status": xrt.StatusOK, "devices": []string{"cached"}},
+			})
+			if err != nil {
+				return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the reply", err)
+			}
+			return [][]byte{reply}, nil
+		},
+		func(ctx context.Context, call *xrt.Call, next xrt.Invoker) ([][]byte, errors.EdgeX) {
+			nextCalled = true
+			return next(ctx, call)
+		},
+	}
+	client, err := xrt.NewXrtClient(context.Background(), bus, "shortcircuit/request", "shortcircuit/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+
+	names, err := client.AllDevices(context.Background())
+	if err != nil || !slices.Equal(names, []string{"cached"}) {
+		t.Fatalf("AllDevices returned %v, %v, want the reply of the interceptor", names, err)
+	}
+	if nextCalled {
+		t.Fatal("the interceptor after the short-circuiting one was called")
+	}
+	if requests := node.Requests(); len(requests) != 0 {
+		t.Fatalf("node received %+v, want the request short-circuited", requests)
+	}
+}
+
+func TestInterceptorRewritesRequestAndReply(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "rewrite/request", ReplyTopic: "rewrite/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	// resolve the alias device name in the request, and report the profile of the device in upper case
+	rewrite := func(ctx context.Context, call *xrt.Call, next xrt.Invoker) ([][]byte, errors.EdgeX) {
+		if call.Op != xrt.OpDeviceGet {
+			return next(ctx, call)
+		}
+		var request map[string]any
+		if err := json.Unmarshal(call.Request, &request); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to decode the request", err)
+		}
+		if request["device"] == "alias" {
+			request["device"] = "device"
+		}
+		data, err := json.Marshal(request)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the request", err)
+		}
+		call.Request = data
+
+		replies, edgexErr := next(ctx, call)
+		if edgexErr != nil {
+			return nil, edgexErr
+		}
+		var reply map[string]any
+		if err = json.Unmarshal(replies[0], &reply); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to decode the reply", err)
+		}
+		reply["result"].(map[string]any)["device"].(map[string]any)["profileName"] = "PROFILE"
+		if replies[0], err = json.Marshal(reply); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the reply", err)
+		}
+		return replies, nil
+	}
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Interceptors = []xrt.Interceptor{rewrite}
+	client, err := xrt.NewXrtClient(context.Background(), bus, "rewrite/request", "rewrite/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err = client.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+
+	device, err := client.DeviceByName(ctx, "alias")
+	if err != nil {
+		t.Fatalf("DeviceByName of the alias failed: %v", err)
+	}
+	if device.ProfileName != "PROFILE" {
+		t.Fatalf("DeviceByName returned the profile %s, want the rewritten reply", device.ProfileName)
+	}
+	if stored, _ := node.Device("device"); stored["profileName"] != "profile" {
+		t.Fatalf("node stored the profile %v, want the rewrite limited to the reply", stored["profileName"])
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package membus provides the in-memory message bus backing xrttest and the capture replayer
+package membus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// MessageBus is an in-memory messaging.MessageClient. Published messages are delivered to every matching
+// subscription in the same process, so xrt.Client and a fake XRT node or a replayer can talk to each other without a
+// broker.
+// Topic filters support the MQTT '+' and '#' wildcards.
+type MessageBus struct {
+	mutex         sync.RWMutex
+	subscriptions []*subscription
+}
+
+var _ messaging.MessageClient = (*MessageBus)(nil)
+
+// subscription delivers messages to a subscriber's channel in publish order without blocking the publisher
+type subscription struct {
+	filter   string
+	binary   bool
+	messages chan<- types.MessageEnvelope
+	errs     chan error
+
+	mutex   sync.Mutex
+	pending []types.MessageEnvelope
+	signal  chan struct{}
+	done    chan struct{}
+}
+
+// NewMessageBus creates an in-memory message bus
+func NewMessageBus() *MessageBus {
+	return &MessageBus{}
+}
+
+func (b *MessageBus) Connect() error {
+	return nil
+}
+
+// Publish delivers the envelope to all subscriptions matching the topic. Binary subscribers receive the
+// JSON-encoded envelope as payload, as they would from a real broker.
+func (b *MessageBus) Publish(message types.MessageEnvelope, topic string) error {
+	message.ReceivedTopic = topic
+	var binaryMessage *types.MessageEnvelope
+	for _, sub := range b.matching(topic) {
+		if !sub.binary {
+			sub.enqueue(message)
+			continue
+		}
+		if binaryMessage == nil {
+			data, err := json.Marshal(message)
+			if err != nil {
+				return fmt.Errorf("failed to marshal message envelope: %w", err)
+			}
+			envelope := types.NewMessageEnvelopeForRequest(data, nil)
+			envelope.ReceivedTopic = topic
+			binaryMessage = &envelope
+		}
+		sub.enqueue(*binaryMessage)
+	}
+	return nil
+}
+
+func (b *MessageBus) PublishWithSizeLimit(message types.MessageEnvelope, topic string, limit int64) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message envelope: %w", err)
+	}
+	if limit > 0 && int64(len(data)) > limit*1024 {
+		return fmt.Errorf("message size %d bytes exceeds the limit of %d KB", len(data), limit)
+	}
+	return b.Publish(message, topic)
+}
+
+// PublishBinaryData delivers the raw data to all subscriptions matching the topic. Envelope subscribers receive
+// the data decoded as a MessageEnvelope, or an error on their error channel if it is not one.
+func (b *MessageBus) PublishBinaryData(data []byte, topic string) error {
+	for _, sub := range b.matching(topic) {
+		if sub.binary {
+			envelope := types.NewMessageEnvelopeForRequest(data, nil)
+			envelope.ReceivedTopic = topic
+			sub.enqueue(envelope)
+			continue
+		}
+		var envelope types.MessageEnvelope
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			sub.reportError(fmt.Errorf("failed to decode message envelope from topic %s: %w", topic, err))
+			continue
+		}
+		envelope.ReceivedTopic = topic
+		sub.enqueue(envelope)
+	}
+	return nil
+}
+
+func (b *MessageBus) Subscribe(topics []types.TopicChannel, messageErrors chan error) error {
+	return b.subscribe(topics, messageErrors, false)
+}
+
+func (b *MessageBus) SubscribeBinaryData(topics []types.TopicChannel, messageErrors chan error) error {
+	return b.subscribe(topics, messageErrors, true)
+}
+
+// Request publishes the request and waits for an envelope with the same RequestID on responseTopicPrefix/<RequestID>
+func (b *MessageBus) Request(message types.MessageEnvelope, requestTopic string, responseTopicPrefix string, timeout time.Duration) (*types.MessageEnvelope, error) {
+	responseTopic := strings.TrimSuffix(responseTopicPrefix, "/") + "/" + message.RequestID
+	responses := make(chan types.MessageEnvelope, 1)
+	if err := b.Subscribe([]types.TopicChannel{{Topic: responseTopic, Messages: responses}}, nil); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = b.Unsubscribe(responseTopic)
+	}()
+
+	if err := b.Publish(message, requestTopic); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response := <-responses:
+		return &response, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("timed out waiting for response on topic %s", responseTopic)
+	}
+}
+
+// Unsubscribe removes every subscription registered with one of the given topic filters
+func (b *MessageBus) Unsubscribe(topics ...string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	remaining := b.subscriptions[:0]
+	for _, sub := range b.subscriptions {
+		if slices.Contains(topics, sub.filter) {
+			close(sub.done)
+			continue
+		}
+		remaining = append(remaining, sub)
+	}
+	b.subscriptions = remaining
+	return nil
+}
+
+// Disconnect removes all subscriptions
+func (b *MessageBus) Disconnect() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, sub := range b.subscriptions {
+		close(sub.done)
+	}
+	b.subscriptions = nil
+	return nil
+}
+
+func (b *MessageBus) subscribe(topics []types.TopicChannel, messageErrors chan error, binary bool) error {
+	for _, topic := range topics {
+		if topic.Topic == "" {
+			return errors.New("topic must not be empty")
+		}
+		if topic.Messages == nil {
+			return fmt.Errorf("message channel of topic %s must not be nil", topic.Topic)
+		}
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, topic := range topics {
+		sub := &subscription{
+			filter:   topic.Topic,
+			binary:   binary,
+			messages: topic.Messages,
+			errs:     messageErrors,
+			signal:   make(chan struct{}, 1),
+			done:     make(chan struct{}),
+		}
+		go sub.deliver()
+		b.subscriptions = append(b.subscriptions, sub)
+	}
+	return nil
+}
+
+func (b *MessageBus) matching(topic string) []*subscription {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	var subs []*subscription
+	for _, sub := range b.subscriptions {
+		if TopicMatches(sub.filter, topic) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+func (s *subscription) enqueue(message types.MessageEnvelope) {
+	s.mutex.Lock()
+	s.pending = append(s.pending, message)
+	s.mutex.Unlock()
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscription) reportError(err error) {
+	if s.errs == nil {
+		return
+	}
+	go func() {
+		select {
+		case s.errs <- err:
+		case <-s.done:
+		}
+	}()
+}
+
+// deliver forwards queued messages to the subscriber until the subscription is removed
+func (s *subscription) deliver() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.signal:
+		}
+
+		s.mutex.Lock()
+		pending := s.pending
+		s.pending = nil
+		s.mutex.Unlock()
+
+		for _, message := range pending {
+			select {
+			case s.messages <- message:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// TopicMatches reports whether the topic matches the MQTT topic filter, which may contain '+' and '#' wildcards
+func TopicMatches(filter string, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) {
+			return false
+		}
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2026 IOTech Ltd
+
+// Package parallel runs the items of a batch with bounded parallelism, for the bulk operations of xrt.Client and the
+// fleet operations
+package parallel
+
+import "sync"
+
+// ForEach calls run for the items 0 to n-1 in order, with at most limit calls running at a time, and returns once
+// every call returned. skip, if set, is called on the calling goroutine right before an item would start; the item
+// isn't run if it reports true, so skip records the outcome of the items it skips. A panic of run is recovered and
+// reported to onPanic along with the item, so it neither crashes the process nor keeps the other items from running.
+func ForEach(n int, limit int, skip func(i int) bool, run func(i int), onPanic func(i int, recovered any)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, max(limit, 1))
+	for i := range n {
+		slots <- struct{}{}
+		if skip != nil && skip(i) {
+			<-slots
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					onPanic(i, recovered)
+				}
+			}()
+			run(i)
+		}()
+	}
+	wg.Wait()
+}
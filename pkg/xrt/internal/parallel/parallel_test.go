@@ -0,0 +1,54 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package parallel
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	var running, peak atomic.Int32
+	var mutex sync.Mutex
+	var ran, skipped, panicked []int
+	record := func(items *[]int, i int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		*items = append(*items, i)
+	}
+
+	ForEach(10, 3,
+		func(i int) bool {
+			if i%5 == 4 {
+				record(&skipped, i)
+				return true
+			}
+			return false
+		},
+		func(i int) {
+			peak.Store(max(peak.Load(), running.Add(1)))
+			defer running.Add(-1)
+			time.Sleep(5 * time.Millisecond)
+			if i == 2 {
+				panic("failed")
+			}
+			record(&ran, i)
+		},
+		func(i int, recovered any) {
+			if recovered != "failed" {
+				t.Errorf("recovered %v for item %d", recovered, i)
+			}
+			record(&panicked, i)
+		})
+
+	slices.Sort(ran)
+	if !slices.Equal(ran, []int{0, 1, 3, 5, 6, 7, 8}) || !slices.Equal(skipped, []int{4, 9}) || !slices.Equal(panicked, []int{2}) {
+		t.Fatalf("ran %v, skipped %v and recovered the panic of %v", ran, skipped, panicked)
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("ran %d items at a time, want at most 3", peak.Load())
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// QueuePolicy decides what happens to a request which can't be sent yet because of the Limits
+type QueuePolicy int
+
+const (
+	// QueueBlock makes the request wait for its turn, up to MaxQueueDepth waiting requests per topic
+	QueueBlock QueuePolicy = iota
+	// QueueFailFast rejects the request with ErrLimitExceeded instead of waiting
+	QueueFailFast
+	// QueueDropOldest makes the request wait for its turn; once MaxQueueDepth requests are waiting, the longest
+	// waiting one fails with ErrLimitExceeded to make room
+	QueueDropOldest
+)
+
+// RateLimit is a token bucket refilled at Rate tokens per second and holding up to Burst tokens
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limits protects XRT nodes from being flooded with requests
+type Limits struct {
+	// MaxInFlightPerTopic is the maximum number of requests per request topic awaiting their reply, zero means no limit
+	MaxInFlightPerTopic int
+	// RateLimits limit the rate of requests per operation category, categories without a RateLimit aren't limited
+	RateLimits map[OpCategory]RateLimit
+	// QueuePolicy decides what happens to requests exceeding a limit
+	QueuePolicy QueuePolicy
+	// MaxQueueDepth is the maximum number of requests per request topic waiting for a rate limit token, and likewise
+	// for an in-flight slot, zero means no limit
+	MaxQueueDepth int
+}
+
+// LimitStats is a snapshot of the queues and wait times of the client's Limits
+type LimitStats struct {
+	Topics     map[string]TopicLimitStats
+	Categories map[OpCategory]CategoryLimitStats
+}
+
+// TopicLimitStats describes the requests of a request topic
+type TopicLimitStats struct {
+	// InFlight is the number of requests awaiting their reply
+	InFlight int
+	// QueueDepth is the number of requests waiting for an in-flight slot
+	QueueDepth int
+	// TokenQueueDepth is the number of requests waiting for a rate limit token
+	TokenQueueDepth int
+	// Rejected counts the requests failed fast because of a limit or a full queue
+	Rejected uint64
+	// Dropped counts the requests dropped from the queue by QueueDropOldest
+	Dropped uint64
+}
+
+// CategoryLimitStats describes the time requests of an operation category waited for the limits
+type CategoryLimitStats struct {
+	Requests  uint64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// limiter enforces the Limits of a client
+type limiter struct {
+	limits Limits
+
+	mutex      sync.Mutex
+	topics     map[string]*topicQueue
+	buckets    map[OpCategory]*tokenBucket
+	categories map[OpCategory]CategoryLimitStats
+}
+
+// topicQueue holds the in-flight slots of a request topic and the requests waiting for one or for a rate limit token
+type topicQueue struct {
+	inFlight      int
+	waiting       []*slotWaiter
+	waitingTokens []*tokenWaiter
+	rejected      uint64
+	dropped       uint64
+}
+
+// slotWaiter receives true when it is handed an in-flight slot, or false when it is dropped from the queue
+type slotWaiter struct {
+	ready chan bool
+}
+
+// tokenWaiter waits for the bucket to be refilled up to its token, dropped is closed when it is dropped from the queue
+type tokenWaiter struct {
+	bucket  *tokenBucket
+	dropped chan struct{}
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(limits Limits) *limiter {
+	l := &limiter{
+		limits:     limits,
+		topics:     make(map[string]*topicQueue),
+		buckets:    make(map[OpCategory]*tokenBucket),
+		categories: make(map[OpCategory]CategoryLimitStats),
+	}
+	for category, rateLimit := range limits.RateLimits {
+		if rateLimit.Rate <= 0 {
+			continue
+		}
+		burst := float64(max(rateLimit.Burst, 1))
+		l.buckets[category] = &tokenBucket{rate: rateLimit.Rate, burst: burst, tokens: burst, last: time.Now()}
+	}
+	return l
+}
+
+// acquire waits until the request of the op may be published to the topic and returns the func releasing its
+// in-flight slot. A nil limiter doesn't limit anything.
+func (l *limiter) acquire(ctx context.Context, op string, topic string) (func(), errors.EdgeX) {
+	if l == nil {
+		return func() {}, nil
+	}
+	category := CategoryOf(op)
+	start := time.Now()
+
+	if err := l.takeToken(ctx, category, topic); err != nil {
+		return nil, err
+	}
+	release, err := l.takeSlot(ctx, topic)
+	if err != nil {
+		l.returnToken(category)
+		return nil, err
+	}
+
+	wait := time.Since(start)
+	l.mutex.Lock()
+	stats := l.categories[category]
+	stats.Requests++
+	stats.TotalWait += wait
+	stats.MaxWait = max(stats.MaxWait, wait)
+	l.categories[category] = stats
+	l.mutex.Unlock()
+	return release, nil
+}
+
+// takeToken takes a token from the bucket of the category, queueing up according to the policy while it is refilled
+func (l *limiter) takeToken(ctx context.Context, category OpCategory, topic string) errors.EdgeX {
+	l.mutex.Lock()
+	bucket, ok := l.buckets[category]
+	if !ok {
+		l.mutex.Unlock()
+		return nil
+	}
+	bucket.refill(time.Now())
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		l.mutex.Unlock()
+		return nil
+	}
+
+	queue := l.topic(topic)
+	queueFull := l.limits.MaxQueueDepth > 0 && len(queue.waitingTokens) >= l.limits.MaxQueueDepth
+	switch {
+	case l.limits.QueuePolicy == QueueFailFast:
+		queue.rejected++
+		l.mutex.Unlock()
+		return errors.NewCommonEdgeX(errors.KindLimitExceeded, fmt.Sprintf("rate limit of %s requests exceeded", category), ErrLimitExceeded)
+	case queueFull && l.limits.QueuePolicy == QueueDropOldest:
+		oldest := queue.waitingTokens[0]
+		queue.waitingTokens = queue.waitingTokens[1:]
+		queue.dropped++
+		// give the token of the dropped request back, it won't be sent
+		oldest.bucket.tokens++
+		close(oldest.dropped)
+	case queueFull:
+		queue.rejected++
+		queued := len(queue.waitingTokens)
+		l.mutex.Unlock()
+		return errors.NewCommonEdgeX(errors.KindLimitExceeded,
+			fmt.Sprintf("%d requests queued for the rate limit of %s requests on topic %s", queued, category, topic), ErrLimitExceeded)
+	}
+
+	bucket.tokens--
+	wait := bucket.wait()
+	waiter := &tokenWaiter{bucket: bucket, dropped: make(chan struct{})}
+	queue.waitingTokens = append(queue.waitingTokens, waiter)
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	var err errors.EdgeX
+	select {
+	case <-waiter.dropped:
+	case <-ctx.Done():
+		err = contextError(ctx)
+	case <-timer.C:
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	i := slices.Index(queue.waitingTokens, waiter)
+	if i < 0 {
+		// dropped from the queue, possibly at the same time as the wait ended
+		return errors.NewCommonEdgeX(errors.KindLimitExceeded,
+			fmt.Sprintf("request dropped from the rate limit queue of topic %s", topic), ErrLimitExceeded)
+	}
+	queue.waitingTokens = slices.Delete(queue.waitingTokens, i, i+1)
+	if err != nil {
+		// give the token back, the request won't be sent
+		bucket.tokens++
+	}
+	return err
+}
+
+// returnToken gives the token taken for a request back to the bucket of the category, as the request won't be sent
+func (l *limiter) returnToken(category OpCategory) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if bucket, ok := l.buckets[category]; ok {
+		bucket.tokens = min(bucket.burst, bucket.tokens+1)
+	}
+}
+
+// takeSlot takes an in-flight slot of the topic, queueing up according to the policy if there is none left
+func (l *limiter) takeSlot(ctx context.Context, topic string) (func(), errors.EdgeX) {
+	l.mutex.Lock()
+	queue := l.topic(topic)
+	release := func() { l.releaseSlot(topic) }
+	if l.limits.MaxInFlightPerTopic <= 0 || (queue.inFlight < l.limits.MaxInFlightPerTopic && len(queue.waiting) == 0) {
+		queue.inFlight++
+		l.mutex.Unlock()
+		return release, nil
+	}
+
+	queueFull := l.limits.MaxQueueDepth > 0 && len(queue.waiting) >= l.limits.MaxQueueDepth
+	switch {
+	case l.limits.QueuePolicy == QueueFailFast:
+		queue.rejected++
+		inFlight := queue.inFlight
+		l.mutex.Unlock()
+		return nil, errors.NewCommonEdgeX(errors.KindLimitExceeded,
+			fmt.Sprintf("%d requests in flight on topic %s", inFlight, topic), ErrLimitExceeded)
+	case queueFull && l.limits.QueuePolicy == QueueDropOldest:
+		oldest := queue.waiting[0]
+		queue.waiting = queue.waiting[1:]
+		queue.dropped++
+		oldest.ready <- false
+	case queueFull:
+		queue.rejected++
+		queued := len(queue.waiting)
+		l.mutex.Unlock()
+		return nil, errors.NewCommonEdgeX(errors.KindLimitExceeded,
+			fmt.Sprintf("%d requests queued on topic %s", queued, topic), ErrLimitExceeded)
+	}
+
+	waiter := &slotWaiter{ready: make(chan bool, 1)}
+	queue.waiting = append(queue.waiting, waiter)
+	l.mutex.Unlock()
+
+	select {
+	case granted := <-waiter.ready:
+		if !granted {
+			return nil, errors.NewCommonEdgeX(errors.KindLimitExceeded,
+				fmt.Sprintf("request dropped from the queue of topic %s", topic), ErrLimitExceeded)
+		}
+		return release, nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		if i := slices.Index(queue.waiting, waiter); i >= 0 {
+			queue.waiting = slices.Delete(queue.waiting, i, i+1)
+			l.mutex.Unlock()
+			return nil, contextError(ctx)
+		}
+		l.mutex.Unlock()
+		// the waiter was handed a slot or dropped meanwhile, pass a handed slot on
+		if <-waiter.ready {
+			release()
+		}
+		return nil, contextError(ctx)
+	}
+}
+
+// releaseSlot hands the in-flight slot to the longest waiting request, or frees it if none is waiting
+func (l *limiter) releaseSlot(topic string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	queue := l.topic(topic)
+	if len(queue.waiting) > 0 {
+		waiter := queue.waiting[0]
+		queue.waiting = queue.waiting[1:]
+		waiter.ready <- true
+		return
+	}
+	queue.inFlight--
+}
+
+func (l *limiter) topic(topic string) *topicQueue {
+	queue, ok := l.topics[topic]
+	if !ok {
+		queue = &topicQueue{}
+		l.topics[topic] = queue
+	}
+	return queue
+}
+
+func (l *limiter) stats() LimitStats {
+	stats := LimitStats{
+		Topics:     make(map[string]TopicLimitStats),
+		Categories: make(map[OpCategory]CategoryLimitStats),
+	}
+	if l == nil {
+		return stats
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for topic, queue := range l.topics {
+		stats.Topics[topic] = TopicLimitStats{
+			InFlight:        queue.inFlight,
+			QueueDepth:      len(queue.waiting),
+			TokenQueueDepth: len(queue.waitingTokens),
+			Rejected:        queue.rejected,
+			Dropped:         queue.dropped,
+		}
+	}
+	for category, categoryStats := range l.categories {
+		stats.Categories[category] = categoryStats
+	}
+	return stats
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// wait returns how long it takes until the bucket is no longer in debt
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// LimitStats returns the current queue depths and the wait times caused by the Limits of the client
+func (c *Client) LimitStats() LimitStats {
+	return c.limiter.stats()
+}
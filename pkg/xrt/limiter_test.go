@@ -0,0 +1,332 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+const limiterTopic = "limiter/request"
+
+type acquireResult struct {
+	release func()
+	err     errors.EdgeX
+}
+
+// acquireAsync acquires in the background, the result is sent on the returned channel
+func acquireAsync(ctx context.Context, l *limiter, op string) <-chan acquireResult {
+	result := make(chan acquireResult, 1)
+	go func() {
+		release, err := l.acquire(ctx, op, limiterTopic)
+		result <- acquireResult{release: release, err: err}
+	}()
+	return result
+}
+
+// waitForQueue waits until the given numbers of requests wait for an in-flight slot and for a token
+func waitForQueue(t *testing.T, l *limiter, slots int, tokens int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		stats := l.stats().Topics[limiterTopic]
+		if stats.QueueDepth == slots && stats.TokenQueueDepth == tokens {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("queue stats are %+v, want %d waiting for a slot and %d for a token", l.stats().Topics[limiterTopic], slots, tokens)
+}
+
+func awaitResult(t *testing.T, result <-chan acquireResult) acquireResult {
+	t.Helper()
+	select {
+	case r := <-result:
+		return r
+	case <-time.After(time.Second):
+		t.Fatal("acquire didn't return")
+		return acquireResult{}
+	}
+}
+
+func assertPending(t *testing.T, result <-chan acquireResult) {
+	t.Helper()
+	select {
+	case r := <-result:
+		t.Fatalf("acquire returned %v while it should be queued", r.err)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestLimiterSlotHandOff(t *testing.T) {
+	l := newLimiter(Limits{MaxInFlightPerTopic: 1})
+	release, err := l.acquire(context.Background(), OpDeviceGet, limiterTopic)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	second := acquireAsync(context.Background(), l, OpDeviceGet)
+	waitForQueue(t, l, 1, 0)
+	assertPending(t, second)
+
+	release()
+	r := awaitResult(t, second)
+	if r.err != nil {
+		t.Fatalf("queued acquire failed: %v", r.err)
+	}
+	if stats := l.stats().Topics[limiterTopic]; stats.InFlight != 1 || stats.QueueDepth != 0 {
+		t.Fatalf("stats after hand-off are %+v, want the slot in flight", stats)
+	}
+	r.release()
+	if stats := l.stats().Topics[limiterTopic]; stats.InFlight != 0 {
+		t.Fatalf("stats after release are %+v, want no request in flight", stats)
+	}
+}
+
+func TestLimiterSlotDropOldest(t *testing.T) {
+	l := newLimiter(Limits{MaxInFlightPerTopic: 1, MaxQueueDepth: 1, QueuePolicy: QueueDropOldest})
+	release, err := l.acquire(context.Background(), OpDeviceGet, limiterTopic)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	oldest := acquireAsync(context.Background(), l, OpDeviceGet)
+	waitForQueue(t, l, 1, 0)
+	newest := acquireAsync(context.Background(), l, OpDeviceGet)
+
+	if r := awaitResult(t, oldest); !stdErrors.Is(r.err, ErrLimitExceeded) {
+		t.Fatalf("oldest queued acquire returned %v, want ErrLimitExceeded", r.err)
+	}
+	waitForQueue(t, l, 1, 0)
+	release()
+	r := awaitResult(t, newest)
+	if r.err != nil {
+		t.Fatalf("newest queued acquire failed: %v", r.err)
+	}
+	r.release()
+	if stats := l.stats().Topics[limiterTopic]; stats.Dropped != 1 || stats.InFlight != 0 {
+		t.Fatalf("stats are %+v, want one dropped request and none in flight", stats)
+	}
+}
+
+func TestLimiterSlotQueueFull(t *testing.T) {
+	l := newLimiter(Limits{MaxInFlightPerTopic: 1, MaxQueueDepth: 1})
+	release, err := l.acquire(context.Background(), OpDeviceGet, limiterTopic)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queued := acquireAsync(ctx, l, OpDeviceGet)
+	waitForQueue(t, l, 1, 0)
+
+	if _, err = l.acquire(context.Background(), OpDeviceGet, limiterTopic); !stdErrors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("acquire with a full queue returned %v, want ErrLimitExceeded", err)
+	}
+	assertPending(t, queued)
+	if stats := l.stats().Topics[limiterTopic]; stats.Rejected != 1 {
+		t.Fatalf("stats are %+v, want one rejected request", stats)
+	}
+}
+
+func TestLimiterCancelWhileQueued(t *testing.T) {
+	l := newLimiter(Limits{MaxInFlightPerTopic: 1})
+	release, err := l.acquire(context.Background(), OpDeviceGet, limiterTopic)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := acquireAsync(ctx, l, OpDeviceGet)
+	waitForQueue(t, l, 1, 0)
+	next := acquireAsync(context.Background(), l, OpDeviceGet)
+	waitForQueue(t, l, 2, 0)
+
+	cancel()
+	if r := awaitResult(t, cancelled); !stdErrors.Is(r.err, context.Canceled) {
+		t.Fatalf("cancelled acquire returned %v, want context.Canceled", r.err)
+	}
+	waitForQueue(t, l, 1, 0)
+
+	release()
+	r := awaitResult(t, next)
+	if r.err != nil {
+		t.Fatalf("acquire queued behind the cancelled one failed: %v", r.err)
+	}
+	r.release()
+	if stats := l.stats().Topics[limiterTopic]; stats.InFlight != 0 || stats.QueueDepth != 0 {
+		t.Fatalf("stats are %+v, want the slot to be free", stats)
+	}
+}
+
+func TestLimiterTokenRefund(t *testing.T) {
+	l := newLimiter(Limits{RateLimits: map[OpCategory]RateLimit{CategoryRead: {Rate: 1, Burst: 1}}})
+	if _, err := l.acquire(context.Background(), OpDeviceRead, limiterTopic); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, OpDeviceRead, limiterTopic); !stdErrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire returned %v, want the expired deadline", err)
+	}
+
+	l.mutex.Lock()
+	tokens := l.buckets[CategoryRead].tokens
+	l.mutex.Unlock()
+	if tokens < 0 {
+		t.Fatalf("bucket holds %f tokens, want the token of the expired request back", tokens)
+	}
+	if stats := l.stats().Topics[limiterTopic]; stats.TokenQueueDepth != 0 {
+		t.Fatalf("stats are %+v, want no request waiting for a token", stats)
+	}
+}
+
+func TestLimiterTokenRefundOnSlotFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        QueuePolicy
+		maxQueueDepth int
+		timeout       time.Duration
+		want          error
+	}{
+		{"fail fast", QueueFailFast, 0, time.Second, ErrLimitExceeded},
+		{"queue full", QueueBlock, 1, time.Second, ErrLimitExceeded},
+		{"deadline while queued", QueueBlock, 0, 20 * time.Millisecond, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter(Limits{
+				MaxInFlightPerTopic: 1,
+				RateLimits:          map[OpCategory]RateLimit{CategoryRead: {Rate: 0.001, Burst: 3}},
+				QueuePolicy:         tt.policy,
+				MaxQueueDepth:       tt.maxQueueDepth,
+			})
+			release, err := l.acquire(context.Background(), OpDeviceRead, limiterTopic)
+			if err != nil {
+				t.Fatalf("acquire failed: %v", err)
+			}
+			defer release()
+			if tt.maxQueueDepth > 0 {
+				queueCtx, cancel := context.WithCancel(context.Background())
+				queued := acquireAsync(queueCtx, l, OpDeviceRead)
+				waitForQueue(t, l, tt.maxQueueDepth, 0)
+				defer func() {
+					cancel()
+					awaitResult(t, queued)
+				}()
+			}
+
+			l.mutex.Lock()
+			before := l.buckets[CategoryRead].tokens
+			l.mutex.Unlock()
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			if _, err = l.acquire(ctx, OpDeviceRead, limiterTopic); !stdErrors.Is(err, tt.want) {
+				t.Fatalf("acquire returned %v, want %v", err, tt.want)
+			}
+			l.mutex.Lock()
+			after := l.buckets[CategoryRead].tokens
+			l.mutex.Unlock()
+			if after < before {
+				t.Fatalf("bucket holds %f tokens after the slot wait failed, want the %f tokens from before", after, before)
+			}
+		})
+	}
+}
+
+func TestLimiterTokenDropOldest(t *testing.T) {
+	l := newLimiter(Limits{
+		RateLimits:    map[OpCategory]RateLimit{CategoryRead: {Rate: 1, Burst: 1}},
+		QueuePolicy:   QueueDropOldest,
+		MaxQueueDepth: 1,
+	})
+	if _, err := l.acquire(context.Background(), OpDeviceRead, limiterTopic); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	oldest := acquireAsync(ctx, l, OpDeviceRead)
+	waitForQueue(t, l, 0, 1)
+	newest := acquireAsync(ctx, l, OpDeviceRead)
+
+	if r := awaitResult(t, oldest); !stdErrors.Is(r.err, ErrLimitExceeded) {
+		t.Fatalf("oldest queued acquire returned %v, want ErrLimitExceeded", r.err)
+	}
+	waitForQueue(t, l, 0, 1)
+	assertPending(t, newest)
+	cancel()
+	awaitResult(t, newest)
+
+	if stats := l.stats().Topics[limiterTopic]; stats.Dropped != 1 || stats.TokenQueueDepth != 0 {
+		t.Fatalf("stats are %+v, want one dropped request and none waiting", stats)
+	}
+}
+
+func TestLimiterTokenQueueFull(t *testing.T) {
+	l := newLimiter(Limits{
+		RateLimits:    map[OpCategory]RateLimit{CategoryRead: {Rate: 1, Burst: 1}},
+		MaxQueueDepth: 1,
+	})
+	if _, err := l.acquire(context.Background(), OpDeviceRead, limiterTopic); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queued := acquireAsync(ctx, l, OpDeviceRead)
+	waitForQueue(t, l, 0, 1)
+
+	if _, err := l.acquire(context.Background(), OpDeviceRead, limiterTopic); !stdErrors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("acquire with a full token queue returned %v, want ErrLimitExceeded", err)
+	}
+	assertPending(t, queued)
+	if stats := l.stats().Topics[limiterTopic]; stats.Rejected != 1 {
+		t.Fatalf("stats are %+v, want one rejected request", stats)
+	}
+}
+
+func TestLimitStats(t *testing.T) {
+	l := newLimiter(Limits{
+		MaxInFlightPerTopic: 1,
+		RateLimits:          map[OpCategory]RateLimit{CategoryRead: {Rate: 1, Burst: 1}},
+		QueuePolicy:         QueueFailFast,
+	})
+	release, err := l.acquire(context.Background(), OpDeviceRead, limiterTopic)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	if _, err = l.acquire(context.Background(), OpDeviceRead, limiterTopic); !stdErrors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("acquire beyond the rate limit returned %v, want ErrLimitExceeded", err)
+	}
+	if _, err = l.acquire(context.Background(), OpDeviceGet, limiterTopic); !stdErrors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("acquire beyond the in-flight limit returned %v, want ErrLimitExceeded", err)
+	}
+	release()
+	if _, err = l.acquire(context.Background(), OpDeviceGet, limiterTopic); err != nil {
+		t.Fatalf("acquire after release failed: %v", err)
+	}
+
+	stats := l.stats()
+	if topic := stats.Topics[limiterTopic]; topic.InFlight != 1 || topic.Rejected != 2 {
+		t.Fatalf("topic stats are %+v, want one request in flight and two rejected", topic)
+	}
+	if read := stats.Categories[CategoryRead]; read.Requests != 1 {
+		t.Fatalf("read stats are %+v, want one request", read)
+	}
+	if metadata := stats.Categories[CategoryMetadata]; metadata.Requests != 1 {
+		t.Fatalf("metadata stats are %+v, want one request", metadata)
+	}
+
+	var nilLimiter *limiter
+	if stats = nilLimiter.stats(); len(stats.Topics) != 0 || len(stats.Categories) != 0 {
+		t.Fatalf("stats of a nil limiter are %+v, want none", stats)
+	}
+}
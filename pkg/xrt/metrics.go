@@ -0,0 +1,25 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// MetricsRecorder receives a measurement for every XRT request attempt sent by the client. Implementations must be
+// safe for concurrent use and must not block.
+type MetricsRecorder interface {
+	// ObserveRequest records an attempt of the op which took duration and failed with err, or succeeded if err is nil.
+	// Multi-reply requests are recorded once their subscribe timeout has elapsed or their stream ended.
+	ObserveRequest(op string, duration time.Duration, err errors.EdgeX)
+}
+
+// observeRequest passes the attempt started at start to the MetricsRecorder of the client, if any
+func (c *Client) observeRequest(op string, start time.Time, err errors.EdgeX) {
+	if c.clientOptions == nil || c.clientOptions.Metrics == nil {
+		return
+	}
+	c.clientOptions.Metrics.ObserveRequest(op, time.Since(start), err)
+}
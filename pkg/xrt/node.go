@@ -0,0 +1,109 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
+	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// NodeIDPlaceholder is replaced by the node id in the topic templates of NodeOptions
+const NodeIDPlaceholder = "{node}"
+
+// NodeOptions provides the config for addressing individual XRT nodes sharing a broker, see Client.ForNode.
+// The addressed nodes must report their node id in result.node of their replies, error results may omit it.
+type NodeOptions struct {
+	// RequestTopicTemplate is the request topic of a node, e.g. edgexpert/xrt/{node}/request
+	RequestTopicTemplate string
+	// CommandTopicTemplate is the command topic of a node, required for the component requests of node views
+	CommandTopicTemplate string
+}
+
+func NewNodeOptions(requestTopicTemplate string, commandTopicTemplate string) *NodeOptions {
+	return &NodeOptions{
+		RequestTopicTemplate: requestTopicTemplate,
+		CommandTopicTemplate: commandTopicTemplate,
+	}
+}
+
+// NodeTopic returns the topic of the node derived from the template
+func NodeTopic(template string, nodeID string) string {
+	return strings.ReplaceAll(template, NodeIDPlaceholder, nodeID)
+}
+
+// ForNode returns a view of the client sending its requests to the XRT node with the given id only. Replies that
+// didn't come from the node are rejected with an error matching ErrNodeMismatch. The view shares the subscriptions,
+// limits, circuit breaker and outbox of the client; closing it is a no-op, close the client it was created from.
+func (c *Client) ForNode(nodeID string) (*Client, errors.EdgeX) {
+	if c.clientOptions == nil || c.clientOptions.Nodes == nil || c.clientOptions.Nodes.RequestTopicTemplate == "" {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "please provide NodeOptions with a RequestTopicTemplate for addressing XRT nodes", nil)
+	}
+	if nodeID == "" || strings.ContainsAny(nodeID, "/+#") {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid XRT node id %q", nodeID), nil)
+	}
+	nodes := c.clientOptions.Nodes
+	if !strings.Contains(nodes.RequestTopicTemplate, NodeIDPlaceholder) {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("RequestTopicTemplate %s lacks the %s placeholder", nodes.RequestTopicTemplate, NodeIDPlaceholder), nil)
+	}
+
+	var commandTopic string
+	if nodes.CommandTopicTemplate != "" {
+		commandTopic = NodeTopic(nodes.CommandTopicTemplate, nodeID)
+	}
+	return c.withNode(nodeID, NodeTopic(nodes.RequestTopicTemplate, nodeID), commandTopic), nil
+}
+
+// NodeID returns the id of the node addressed by a view created with ForNode, or an empty string
+func (c *Client) NodeID() string {
+	return c.nodeID
+}
+
+// withNode returns a view of the client addressing the node on the given topics
+func (c *Client) withNode(nodeID string, requestTopic string, commandTopic string) *Client {
+	view := *c
+	view.nodeID = nodeID
+	view.requestTopic = requestTopic
+	view.nodeCommandTopic = commandTopic
+	return &view
+}
+
+// checkReplyNode rejects the reply of a node view unless it came from the addressed node. Error results are only
+// rejected if they name another node, as XRT doesn't report the node in every error result.
+func (c *Client) checkReplyNode(op string, requestId string, reply []byte) errors.EdgeX {
+	if c.nodeID == "" {
+		return nil
+	}
+	node := replyNode(c.codec, reply)
+	if node == "" && isErrorResult(c.codec, reply) {
+		return nil
+	}
+	if node != c.nodeID {
+		return errors.NewCommonEdgeX(errors.KindCommunicationError,
+			fmt.Sprintf("reply to XRT request %s, requestId: %s, came from node %q instead of %q", op, requestId, node, c.nodeID), ErrNodeMismatch)
+	}
+	return nil
+}
+
+// replyNode returns the node id reported in result.node of the reply, or an empty string
+func replyNode(replyCodec codec.Codec, reply []byte) string {
+	var nodeResponse struct {
+		Result struct {
+			Node string `json:"node"`
+		} `json:"result"`
+	}
+	if err := replyCodec.Unmarshal(reply, &nodeResponse); err != nil {
+		return ""
+	}
+	return nodeResponse.Result.Node
+}
+
+// isErrorResult reports whether the reply carries an error result
+func isErrorResult(replyCodec codec.Codec, reply []byte) bool {
+	var response xrtmodels.CommonResponse
+	return replyCodec.Unmarshal(reply, &response) == nil && response.Result.Error() != nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// replyWith answers every request published on the request topic with the given result
+func replyWith(t *testing.T, bus *xrttest.MessageBus, requestTopic string, replyTopic string, result func(op string) map[string]any) {
+	t.Helper()
+	messages := make(chan types.MessageEnvelope)
+	if err := bus.SubscribeBinaryData([]types.TopicChannel{{Topic: requestTopic, Messages: messages}}, nil); err != nil {
+		t.Fatalf("failed to subscribe to %s: %v", requestTopic, err)
+	}
+	go func() {
+		for message := range messages {
+			var request struct {
+				RequestId string `json:"request_id"`
+				Op        string `json:"op"`
+			}
+			_ = json.Unmarshal(message.Payload.([]byte), &request)
+			reply, _ := json.Marshal(map[string]any{"request_id": request.RequestId, "result": result(request.Op)})
+			_ = bus.PublishBinaryData(reply, replyTopic)
+		}
+	}()
+}
+
+func TestNodeViewReplyNode(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	replyWith(t, bus, "node/request/node-1", "node/reply", func(op string) map[string]any {
+		if op == xrttest.OpDeviceGet {
+			// error results may lack the node
+			return map[string]any{"status": xrt.StatusNotFound, "error": "device not found"}
+		}
+		return map[string]any{"status": xrt.StatusOK, "node": "node-2", "devices": []string{}}
+	})
+
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Nodes = xrt.NewNodeOptions("node/request/"+xrt.NodeIDPlaceholder, "")
+	client, err := xrt.NewXrtClient(context.Background(), bus, "node/request", "node/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+	view, err := client.(*xrt.Client).ForNode("node-1")
+	if err != nil {
+		t.Fatalf("ForNode failed: %v", err)
+	}
+
+	_, err = view.DeviceByName(context.Background(), "missing")
+	if stdErrors.Is(err, xrt.ErrNodeMismatch) || errors.Kind(err) != errors.KindEntityDoesNotExist {
+		t.Fatalf("DeviceByName returned %v of kind %s, want the error result of the node", err, errors.Kind(err))
+	}
+	if _, err = view.AllDevices(context.Background()); !stdErrors.Is(err, xrt.ErrNodeMismatch) {
+		t.Fatalf("AllDevices returned %v for the reply of another node, want ErrNodeMismatch", err)
+	}
+}
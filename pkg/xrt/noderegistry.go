@@ -0,0 +1,273 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/topicmgr"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// DefaultNodeStaleAfter is how long a NodeRegistry waits for a status of a node before marking it stale unless
+// configured otherwise
+const DefaultNodeStaleAfter = time.Minute
+
+// NodeState is the state of an XRT node as seen by a NodeRegistry
+type NodeState string
+
+const (
+	NodeOnline  NodeState = "online"
+	NodeOffline NodeState = "offline"
+	// NodeStale is the state of a node whose last status is older than the stale interval of the registry
+	NodeStale NodeState = "stale"
+)
+
+// NodeStatus is a status message published by an XRT node on the status topic
+type NodeStatus struct {
+	Node string `json:"node"`
+	// Status is offline when the node shuts down, any other value reports the node online
+	Status     string          `json:"status"`
+	Version    string          `json:"version,omitempty"`
+	Components []NodeComponent `json:"components,omitempty"`
+}
+
+// NodeComponent is a component hosted by an XRT node
+type NodeComponent struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+}
+
+// NodeInfo is the known state of an XRT node
+type NodeInfo struct {
+	ID         string
+	State      NodeState
+	LastSeen   time.Time
+	Version    string
+	Components []NodeComponent
+}
+
+// NodeEvent reports a change of a node: its first status, or a change of its state, version or components.
+// Previous is the zero NodeInfo for a node seen for the first time.
+type NodeEvent struct {
+	Previous NodeInfo
+	Current  NodeInfo
+}
+
+// NodeRegistry keeps track of the XRT nodes publishing their status on the status topic. A node is online or offline
+// as reported by its last status, and stale once no status arrived within the stale interval. NodeRegistry is safe for
+// concurrent use.
+type NodeRegistry struct {
+	// OnChange is called with every NodeEvent, it may be called concurrently and must not block
+	OnChange func(event NodeEvent)
+
+	staleAfter time.Duration
+	codec      codec.Codec
+
+	mutex     sync.RWMutex
+	nodes     map[string]*NodeInfo
+	lc        logger.LoggingClient
+	manager   *topicmgr.DispatcherTopicManager
+	handlerID topicmgr.HandlerID
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewNodeRegistry creates a NodeRegistry marking nodes stale after staleAfter, which defaults to
+// DefaultNodeStaleAfter when not positive. statusCodec decodes the status messages, defaults to codec.JSON.
+func NewNodeRegistry(staleAfter time.Duration, statusCodec codec.Codec) *NodeRegistry {
+	if staleAfter <= 0 {
+		staleAfter = DefaultNodeStaleAfter
+	}
+	return &NodeRegistry{
+		staleAfter: staleAfter,
+		codec:      codec.OrDefault(statusCodec),
+		nodes:      make(map[string]*NodeInfo),
+	}
+}
+
+// Start subscribes to the status topic and starts marking silent nodes stale
+func (r *NodeRegistry) Start(messageBus messaging.MessageClient, statusTopic string, lc logger.LoggingClient) errors.EdgeX {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.manager != nil {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("node registry already subscribed to %s", r.manager.Topic), nil)
+	}
+
+	r.lc = lc
+	manager, err := topicmgr.TmPool.GetDispatcherTopicManager(statusTopic, messageBus, lc)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), "failed to subscribe to the status topic", err)
+	}
+	handlerID, err := manager.RegisterHandler(r.handle)
+	if err != nil {
+		topicmgr.TmPool.ReleaseTopicManager(statusTopic)
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	r.manager = manager
+	r.handlerID = handlerID
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go r.watch(r.stop, r.done)
+	return nil
+}
+
+// Stop unsubscribes from the status topic, the known nodes are kept
+func (r *NodeRegistry) Stop() {
+	r.mutex.Lock()
+	manager, stop, done := r.manager, r.stop, r.done
+	if manager == nil {
+		r.mutex.Unlock()
+		return
+	}
+	manager.UnregisterHandler(r.handlerID)
+	topicmgr.TmPool.ReleaseTopicManager(manager.Topic)
+	r.manager = nil
+	r.mutex.Unlock()
+
+	close(stop)
+	<-done
+}
+
+// Nodes returns the known nodes ordered by id
+func (r *NodeRegistry) Nodes() []NodeInfo {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	nodes := make([]NodeInfo, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		nodes = append(nodes, node.clone())
+	}
+	slices.SortFunc(nodes, func(a, b NodeInfo) int { return cmp.Compare(a.ID, b.ID) })
+	return nodes
+}
+
+// Node returns the node with the given id
+func (r *NodeRegistry) Node(id string) (NodeInfo, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	node, ok := r.nodes[id]
+	if !ok {
+		return NodeInfo{}, false
+	}
+	return node.clone(), true
+}
+
+// Online returns the ids of the online nodes in order
+func (r *NodeRegistry) Online() []string {
+	var online []string
+	for _, node := range r.Nodes() {
+		if node.State == NodeOnline {
+			online = append(online, node.ID)
+		}
+	}
+	return online
+}
+
+// Update applies the status to the registry, like a status message received on the status topic
+func (r *NodeRegistry) Update(status NodeStatus) errors.EdgeX {
+	if status.Node == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "node of the XRT status is required", nil)
+	}
+	state := NodeOnline
+	if strings.EqualFold(status.Status, string(NodeOffline)) {
+		state = NodeOffline
+	}
+
+	r.mutex.Lock()
+	node, known := r.nodes[status.Node]
+	var previous NodeInfo
+	if known {
+		previous = node.clone()
+	} else {
+		node = &NodeInfo{ID: status.Node}
+		r.nodes[status.Node] = node
+	}
+	node.State = state
+	node.LastSeen = time.Now()
+	if status.Version != "" {
+		node.Version = status.Version
+	}
+	if status.Components != nil {
+		node.Components = slices.Clone(status.Components)
+	}
+	current := node.clone()
+	r.mutex.Unlock()
+
+	if !known || previous.State != current.State || previous.Version != current.Version ||
+		!slices.Equal(previous.Components, current.Components) {
+		r.notify(NodeEvent{Previous: previous, Current: current})
+	}
+	return nil
+}
+
+// handle decodes and applies the status messages received on the status topic
+func (r *NodeRegistry) handle(message types.MessageEnvelope) {
+	if err := message.ConvertMsgPayloadToByteArray(); err != nil {
+		r.lc.Errorf("failed to convert message payload to byte array: %v", err)
+		return
+	}
+	var status NodeStatus
+	if err := r.codec.Unmarshal(message.Payload.([]byte), &status); err != nil {
+		r.lc.Warnf("failed to parse XRT status, topic: %s, err: %v", message.ReceivedTopic, err)
+		return
+	}
+	if err := r.Update(status); err != nil {
+		r.lc.Warnf("ignoring XRT status received on %s: %v", message.ReceivedTopic, err)
+	}
+}
+
+// watch marks the online nodes stale which haven't sent a status within staleAfter
+func (r *NodeRegistry) watch(stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(max(r.staleAfter/4, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.markStale()
+		}
+	}
+}
+
+func (r *NodeRegistry) markStale() {
+	var events []NodeEvent
+	r.mutex.Lock()
+	now := time.Now()
+	for _, node := range r.nodes {
+		if node.State != NodeOnline || now.Sub(node.LastSeen) < r.staleAfter {
+			continue
+		}
+		previous := node.clone()
+		node.State = NodeStale
+		events = append(events, NodeEvent{Previous: previous, Current: node.clone()})
+	}
+	r.mutex.Unlock()
+
+	for _, event := range events {
+		r.notify(event)
+	}
+}
+
+func (r *NodeRegistry) notify(event NodeEvent) {
+	if r.OnChange != nil {
+		r.OnChange(event)
+	}
+}
+
+func (n *NodeInfo) clone() NodeInfo {
+	info := *n
+	info.Components = slices.Clone(n.Components)
+	return info
+}
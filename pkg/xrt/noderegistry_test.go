@@ -0,0 +1,136 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/internal/membus"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+// startRegistry starts a registry on the status topic of the bus, its events are sent on the returned channel
+func startRegistry(t *testing.T, bus *membus.MessageBus, statusTopic string, staleAfter time.Duration, statusCodec codec.Codec) (*xrt.NodeRegistry, <-chan xrt.NodeEvent) {
+	t.Helper()
+	events := make(chan xrt.NodeEvent, 16)
+	registry := xrt.NewNodeRegistry(staleAfter, statusCodec)
+	registry.OnChange = func(event xrt.NodeEvent) { events <- event }
+	if err := registry.Start(bus, statusTopic, logger.NewMockClient()); err != nil {
+		t.Fatalf("failed to start node registry: %v", err)
+	}
+	t.Cleanup(registry.Stop)
+	return registry, events
+}
+
+// publishStatus publishes the status encoded with statusCodec
+func publishStatus(t *testing.T, bus *membus.MessageBus, statusTopic string, statusCodec codec.Codec, status any) {
+	t.Helper()
+	data, err := statusCodec.Marshal(status)
+	if err != nil {
+		t.Fatalf("failed to encode status: %v", err)
+	}
+	if err = bus.PublishBinaryData(data, statusTopic); err != nil {
+		t.Fatalf("failed to publish status: %v", err)
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan xrt.NodeEvent) xrt.NodeEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no node event reported")
+		return xrt.NodeEvent{}
+	}
+}
+
+func assertNoEvent(t *testing.T, events <-chan xrt.NodeEvent) {
+	t.Helper()
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected node event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNodeRegistryStatus(t *testing.T) {
+	bus := membus.NewMessageBus()
+	registry, events := startRegistry(t, bus, "registry/status", time.Hour, nil)
+
+	components := []any{map[string]any{"name": "mqtt-1", "category": "north"}}
+	publishStatus(t, bus, "registry/status", codec.JSON, map[string]any{"node": "node-2", "status": "online", "version": "2.2", "components": components})
+	event := nextEvent(t, events)
+	if event.Previous.ID != "" || event.Current.ID != "node-2" || event.Current.State != xrt.NodeOnline || event.Current.Version != "2.2" ||
+		!slices.Equal(event.Current.Components, []xrt.NodeComponent{{Name: "mqtt-1", Category: "north"}}) {
+		t.Fatalf("first status reported %+v, want node-2 online with its version and components", event)
+	}
+
+	// statuses without a node or which can't be decoded are ignored
+	publishStatus(t, bus, "registry/status", codec.JSON, map[string]any{"status": "online"})
+	if err := bus.PublishBinaryData([]byte("not a status"), "registry/status"); err != nil {
+		t.Fatalf("failed to publish status: %v", err)
+	}
+	// any status other than offline reports the node online
+	publishStatus(t, bus, "registry/status", codec.JSON, map[string]any{"node": "node-1", "status": "running"})
+	if event = nextEvent(t, events); event.Current.ID != "node-1" || event.Current.State != xrt.NodeOnline {
+		t.Fatalf("status of node-1 reported %+v, want node-1 online", event)
+	}
+	// an unchanged status isn't an event, and a status without version and components keeps the known ones
+	publishStatus(t, bus, "registry/status", codec.JSON, map[string]any{"node": "node-2", "status": "online"})
+	assertNoEvent(t, events)
+
+	publishStatus(t, bus, "registry/status", codec.JSON, map[string]any{"node": "node-2", "status": "OFFLINE"})
+	event = nextEvent(t, events)
+	if event.Previous.State != xrt.NodeOnline || event.Current.State != xrt.NodeOffline || event.Current.Version != "2.2" {
+		t.Fatalf("offline status reported %+v, want node-2 going from online to offline", event)
+	}
+
+	nodes := registry.Nodes()
+	if len(nodes) != 2 || nodes[0].ID != "node-1" || nodes[1].ID != "node-2" {
+		t.Fatalf("Nodes returned %+v, want node-1 and node-2 ordered by id", nodes)
+	}
+	if node, ok := registry.Node("node-2"); !ok || node.State != xrt.NodeOffline || len(node.Components) != 1 || node.LastSeen.IsZero() {
+		t.Fatalf("Node returned %+v, %v, want node-2 offline with its components", node, ok)
+	}
+	if _, ok := registry.Node("node-3"); ok {
+		t.Fatal("Node found a node which never reported its status")
+	}
+	if online := registry.Online(); !slices.Equal(online, []string{"node-1"}) {
+		t.Fatalf("Online returned %v, want [node-1]", online)
+	}
+}
+
+func TestNodeRegistryStale(t *testing.T) {
+	bus := membus.NewMessageBus()
+	registry, events := startRegistry(t, bus, "registry/stale", 100*time.Millisecond, codec.CBOR)
+
+	publishStatus(t, bus, "registry/stale", codec.CBOR, xrt.NodeStatus{Node: "node-1", Status: "online"})
+	publishStatus(t, bus, "registry/stale", codec.CBOR, xrt.NodeStatus{Node: "node-2", Status: "offline"})
+	for range 2 {
+		nextEvent(t, events)
+	}
+
+	// only online nodes become stale
+	event := nextEvent(t, events)
+	if event.Current.ID != "node-1" || event.Previous.State != xrt.NodeOnline || event.Current.State != xrt.NodeStale {
+		t.Fatalf("silent node reported %+v, want node-1 going from online to stale", event)
+	}
+	if time.Since(event.Current.LastSeen) < 100*time.Millisecond {
+		t.Fatalf("node-1 marked stale %v after its last status, before the stale interval", time.Since(event.Current.LastSeen))
+	}
+	assertNoEvent(t, events)
+	if online := registry.Online(); len(online) != 0 {
+		t.Fatalf("Online returned %v, want no online node", online)
+	}
+
+	publishStatus(t, bus, "registry/stale", codec.CBOR, xrt.NodeStatus{Node: "node-1", Status: "online"})
+	if event = nextEvent(t, events); event.Previous.State != xrt.NodeStale || event.Current.State != xrt.NodeOnline {
+		t.Fatalf("status of the stale node reported %+v, want node-1 back online", event)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+// XRT management operations sent by Client, see https://docs.iotechsys.com/edge-xrt22/mqtt-management/mqtt-management.html
+const (
+	OpDeviceList          = "device:list"
+	OpDeviceGet           = "device:get"
+	OpDeviceAdd           = "device:add"
+	OpDeviceUpdate        = "device:update"
+	OpDeviceDelete        = "device:delete"
+	OpDeviceAddDiscovered = "device:add_discovered"
+	OpDeviceScan          = "device:scan"
+	OpDeviceRead          = "device:read"
+	OpDeviceWrite         = "device:write"
+	OpDiscoveryTrigger    = "discovery:trigger"
+	OpProfileList         = "profile:list"
+	OpProfileGet          = "profile:get"
+	OpProfileAdd          = "profile:add"
+	OpProfileUpdate       = "profile:update"
+	OpProfileDelete       = "profile:delete"
+	OpScheduleList        = "schedule:list"
+	OpScheduleRead        = "schedule:read"
+	OpScheduleAdd         = "schedule:add"
+	OpScheduleUpdate      = "schedule:update"
+	OpScheduleDelete      = "schedule:delete"
+	OpComponentUpdate     = "component:update"
+	OpComponentDiscover   = "component:discover"
+)
+
+var readOnlyOps = map[string]bool{
+	OpDeviceList:        true,
+	OpDeviceGet:         true,
+	OpDeviceRead:        true,
+	OpProfileList:       true,
+	OpProfileGet:        true,
+	OpScheduleList:      true,
+	OpScheduleRead:      true,
+	OpComponentDiscover: true,
+}
+
+// IsReadOnlyOp reports whether the XRT operation only reads state, so sending it more than once has no side effect
+func IsReadOnlyOp(op string) bool {
+	return readOnlyOps[op]
+}
+
+var mutatingOps = map[string]bool{
+	OpDeviceAdd:           true,
+	OpDeviceUpdate:        true,
+	OpDeviceDelete:        true,
+	OpDeviceAddDiscovered: true,
+	OpDeviceWrite:         true,
+	OpProfileAdd:          true,
+	OpProfileUpdate:       true,
+	OpProfileDelete:       true,
+	OpScheduleAdd:         true,
+	OpScheduleUpdate:      true,
+	OpScheduleDelete:      true,
+	OpComponentUpdate:     true,
+}
+
+// IsMutatingOp reports whether the XRT operation changes the configuration or the device state of the node, which
+// makes it subject to the AuditLog
+func IsMutatingOp(op string) bool {
+	return mutatingOps[op]
+}
+
+// OpCategory groups XRT operations by the load they put on the XRT node
+type OpCategory string
+
+const (
+	// CategoryRead covers reading device resources
+	CategoryRead OpCategory = "read"
+	// CategoryWrite covers writing device resources
+	CategoryWrite OpCategory = "write"
+	// CategoryScan covers scanning devices for their profile
+	CategoryScan OpCategory = "scan"
+	// CategoryDiscovery covers device and component discovery
+	CategoryDiscovery OpCategory = "discovery"
+	// CategoryMetadata covers managing devices, profiles, schedules and components
+	CategoryMetadata OpCategory = "metadata"
+)
+
+var opCategories = map[string]OpCategory{
+	OpDeviceRead:        CategoryRead,
+	OpDeviceWrite:       CategoryWrite,
+	OpDeviceScan:        CategoryScan,
+	OpDiscoveryTrigger:  CategoryDiscovery,
+	OpComponentDiscover: CategoryDiscovery,
+}
+
+// CategoryOf returns the category of the XRT operation, operations not listed elsewhere are CategoryMetadata
+func CategoryOf(op string) OpCategory {
+	if category, ok := opCategories[op]; ok {
+		return category
+	}
+	return CategoryMetadata
+}
@@ -0,0 +1,416 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/google/uuid"
+)
+
+// DefaultOutboxRetryInterval is how often an Outbox retries to send its queued operations unless configured otherwise
+const DefaultOutboxRetryInterval = 5 * time.Second
+
+const outboxFileExt = ".json"
+
+// OutboxEntry is a mutating operation queued by an Outbox
+type OutboxEntry struct {
+	Id        string `json:"id"`
+	Op        string `json:"op"`
+	Topic     string `json:"topic"`
+	RequestId string `json:"request_id"`
+	// NodeID is the node addressed by the operation when it was sent through a node view, see Client.ForNode
+	NodeID string `json:"node_id,omitempty"`
+	// Codec is the name of the codec the request is encoded with, the client replaying it must use the same codec
+	Codec string `json:"codec"`
+	// Request is the xrtmodels request as encoded by the client, it is replayed as is
+	Request []byte        `json:"request"`
+	Timeout time.Duration `json:"timeout"`
+	// Caller is the caller of the operation, see WithCaller
+	Caller string `json:"caller,omitempty"`
+	// Retry is set for operations sent with WithRetry, whose replay is retried like the original request
+	Retry    bool      `json:"retry,omitempty"`
+	QueuedAt time.Time `json:"queued_at"`
+	// Attempts counts the replays which couldn't send the request yet
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+
+	seq uint64
+}
+
+// QueuedError is the error returned for an operation queued by the Outbox, it matches ErrQueued
+type QueuedError struct {
+	// Id identifies the operation in the Outbox
+	Id string
+	Op string
+}
+
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("XRT request %s queued as %s until the message bus is available", e.Op, e.Id)
+}
+
+func (e *QueuedError) Unwrap() error {
+	return ErrQueued
+}
+
+// Outbox is a durable queue of mutating operations, see IsMutatingOp, which couldn't be published because the message
+// bus was unavailable. Each operation is kept as a file in the directory of the Outbox, so it survives restarts.
+// Queued operations are replayed in order every RetryInterval, or on Replay, until they are published; operations
+// sent while others are still queued are queued as well to keep the order. Once an operation has been published its
+// outcome, the reply of XRT, is reported to OnOutcome and the operation is removed.
+// Operations are kept as encoded by the client, so the clients using an Outbox must share the codec. An Outbox must
+// only be used by one client at a time.
+type Outbox struct {
+	// OnOutcome is called with the outcome of each queued operation once it has been replayed or cancelled, it must
+	// not block
+	OnOutcome func(entry OutboxEntry, err errors.EdgeX)
+
+	dir           string
+	retryInterval time.Duration
+
+	mutex    sync.Mutex
+	entries  []*OutboxEntry
+	nextSeq  uint64
+	inFlight string
+	client   *Client
+	wake     chan struct{}
+	stop     context.CancelFunc
+	done     chan struct{}
+}
+
+// NewOutbox creates an Outbox keeping its operations in dir, and loads the operations left in it. retryInterval
+// defaults to DefaultOutboxRetryInterval when not positive.
+func NewOutbox(dir string, retryInterval time.Duration) (*Outbox, errors.EdgeX) {
+	if dir == "" {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "directory of the outbox is required", nil)
+	}
+	if retryInterval <= 0 {
+		retryInterval = DefaultOutboxRetryInterval
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to create outbox directory %s", dir), err)
+	}
+	o := &Outbox{dir: dir, retryInterval: retryInterval, nextSeq: 1, wake: make(chan struct{}, 1)}
+	if err := o.load(); err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return o, nil
+}
+
+// Pending returns the queued operations in replay order
+func (o *Outbox) Pending() []OutboxEntry {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	pending := make([]OutboxEntry, 0, len(o.entries))
+	for _, entry := range o.entries {
+		pending = append(pending, *entry)
+	}
+	return pending
+}
+
+// Cancel removes the queued operation, which is reported to OnOutcome with an error wrapping context.Canceled.
+// An operation being replayed can't be cancelled.
+func (o *Outbox) Cancel(id string) errors.EdgeX {
+	o.mutex.Lock()
+	if o.inFlight == id {
+		o.mutex.Unlock()
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("queued operation %s is being replayed", id), nil)
+	}
+	i := slices.IndexFunc(o.entries, func(entry *OutboxEntry) bool { return entry.Id == id })
+	if i < 0 {
+		o.mutex.Unlock()
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("queued operation %s not found", id), nil)
+	}
+	entry := o.entries[i]
+	if err := o.remove(entry); err != nil {
+		o.mutex.Unlock()
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	o.entries = slices.Delete(o.entries, i, i+1)
+	o.mutex.Unlock()
+
+	o.report(*entry, errors.NewCommonEdgeX(KindCanceled, fmt.Sprintf("queued operation %s cancelled", id), context.Canceled))
+	return nil
+}
+
+// Replay makes the Outbox retry to send its queued operations now, e.g. once the message bus reconnected
+func (o *Outbox) Replay() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) empty() bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return len(o.entries) == 0
+}
+
+// enqueue persists the operation and appends it to the queue
+func (o *Outbox) enqueue(op string, topic string, requestId string, nodeID string, request encodedRequest, timeout time.Duration, caller string, retry bool) (OutboxEntry, errors.EdgeX) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	entry := &OutboxEntry{
+		Id:        uuid.NewString(),
+		Op:        op,
+		Topic:     topic,
+		RequestId: requestId,
+		NodeID:    nodeID,
+		Codec:     request.codec.Name(),
+		Request:   request.data,
+		Timeout:   timeout,
+		Caller:    caller,
+		Retry:     retry,
+		QueuedAt:  time.Now(),
+		seq:       o.nextSeq,
+	}
+	if edgexErr := o.persist(entry); edgexErr != nil {
+		return OutboxEntry{}, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	o.nextSeq++
+	o.entries = append(o.entries, entry)
+	return *entry, nil
+}
+
+// attach starts replaying the queued operations with the client
+func (o *Outbox) attach(c *Client) errors.EdgeX {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.client != nil {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("outbox %s is already used by another client", o.dir), nil)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	o.client = c
+	o.stop = stop
+	o.done = make(chan struct{})
+	go o.run(ctx, c, o.done)
+	return nil
+}
+
+// detach stops replaying, the queued operations are kept for the next client
+func (o *Outbox) detach(c *Client) {
+	o.mutex.Lock()
+	if o.client != c {
+		o.mutex.Unlock()
+		return
+	}
+	stop, done := o.stop, o.done
+	o.client = nil
+	o.mutex.Unlock()
+
+	stop()
+	<-done
+}
+
+func (o *Outbox) run(ctx context.Context, c *Client, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(o.retryInterval)
+	defer ticker.Stop()
+	for {
+		o.drain(ctx, c)
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain replays the queued operations in order until the queue is empty or an operation can't be sent yet
+func (o *Outbox) drain(ctx context.Context, c *Client) {
+	for ctx.Err() == nil {
+		o.mutex.Lock()
+		if len(o.entries) == 0 {
+			o.mutex.Unlock()
+			return
+		}
+		entry := o.entries[0]
+		o.inFlight = entry.Id
+		o.mutex.Unlock()
+
+		err := c.replayQueued(ctx, *entry)
+		if requestNotSent(err) {
+			o.retryLater(entry, err)
+			return
+		}
+		o.complete(entry, err)
+	}
+}
+
+// requestNotSent reports whether the error kept the request from being published, so it must be replayed again
+func requestNotSent(err errors.EdgeX) bool {
+	return stdErrors.Is(err, ErrPublishFailed) || stdErrors.Is(err, ErrCircuitOpen) || stdErrors.Is(err, ErrLimitExceeded) ||
+		stdErrors.Is(err, context.Canceled)
+}
+
+func (o *Outbox) retryLater(entry *OutboxEntry, err errors.EdgeX) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.inFlight = ""
+	entry.Attempts++
+	entry.LastError = err.Error()
+	if edgexErr := o.persist(entry); edgexErr != nil && o.client != nil {
+		o.client.lc.Warnf("failed to update queued operation %s: %v", entry.Id, edgexErr)
+	}
+}
+
+func (o *Outbox) complete(entry *OutboxEntry, err errors.EdgeX) {
+	o.mutex.Lock()
+	o.inFlight = ""
+	if i := slices.Index(o.entries, entry); i >= 0 {
+		o.entries = slices.Delete(o.entries, i, i+1)
+	}
+	if edgexErr := o.remove(entry); edgexErr != nil && o.client != nil {
+		o.client.lc.Warnf("failed to remove replayed operation %s: %v", entry.Id, edgexErr)
+	}
+	o.mutex.Unlock()
+
+	o.report(*entry, err)
+}
+
+func (o *Outbox) report(entry OutboxEntry, err errors.EdgeX) {
+	if o.OnOutcome != nil {
+		o.OnOutcome(entry, err)
+	}
+}
+
+// load reads the operations left in the directory, ordered by their sequence number
+func (o *Outbox) load() errors.EdgeX {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to read outbox directory %s", o.dir), err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		seq, parseErr := strconv.ParseUint(strings.TrimSuffix(name, outboxFileExt), 10, 64)
+		if file.IsDir() || !strings.HasSuffix(name, outboxFileExt) || parseErr != nil {
+			continue
+		}
+		data, readErr := os.ReadFile(filepath.Join(o.dir, name))
+		if readErr != nil {
+			return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to read queued operation %s", name), readErr)
+		}
+		entry := &OutboxEntry{}
+		if decodeErr := json.Unmarshal(data, entry); decodeErr != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to decode queued operation %s", name), decodeErr)
+		}
+		entry.seq = seq
+		o.entries = append(o.entries, entry)
+		o.nextSeq = max(o.nextSeq, seq+1)
+	}
+	slices.SortFunc(o.entries, func(a, b *OutboxEntry) int { return cmp.Compare(a.seq, b.seq) })
+	return nil
+}
+
+// persist writes the operation to its file, replacing it atomically. The caller must hold the mutex.
+func (o *Outbox) persist(entry *OutboxEntry) errors.EdgeX {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to encode queued operation %s", entry.Id), err)
+	}
+	path := o.path(entry)
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to write queued operation %s", entry.Id), err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to write queued operation %s", entry.Id), err)
+	}
+	return nil
+}
+
+// remove deletes the file of the operation. The caller must hold the mutex.
+func (o *Outbox) remove(entry *OutboxEntry) errors.EdgeX {
+	if err := os.Remove(o.path(entry)); err != nil && !os.IsNotExist(err) {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to remove queued operation %s", entry.Id), err)
+	}
+	return nil
+}
+
+func (o *Outbox) path(entry *OutboxEntry) string {
+	return filepath.Join(o.dir, fmt.Sprintf("%020d%s", entry.seq, outboxFileExt))
+}
+
+// encodedRequest is an xrtmodels request encoded with the codec of the client, like the requests queued by the Outbox.
+// It is sent as is, so its values keep the precision of their encoding.
+type encodedRequest struct {
+	codec codec.Codec
+	data  []byte
+}
+
+// withRequestId returns a copy of the request with the given RequestId, the other fields are kept as encoded
+func (r encodedRequest) withRequestId(requestId string) (encodedRequest, errors.EdgeX) {
+	setter, ok := r.codec.(codec.FieldSetter)
+	if !ok {
+		return encodedRequest{}, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("codec %s can't set the requestId of an encoded XRT request", r.codec.Name()), nil)
+	}
+	data, err := setter.SetField(r.data, requestIdField, requestId)
+	if err != nil {
+		return encodedRequest{}, errors.NewCommonEdgeX(errors.KindServerError, "failed to set the requestId of an encoded XRT request", err)
+	}
+	return encodedRequest{codec: r.codec, data: data}, nil
+}
+
+// encodeRequest encodes the xrtmodels request with the codec of the client, encoded requests are returned as is
+func (c *Client) encodeRequest(request any) ([]byte, error) {
+	if encoded, ok := request.(encodedRequest); ok {
+		return encoded.data, nil
+	}
+	return c.codec.Marshal(request)
+}
+
+// sendOrQueue sends the mutating request, or queues it in the Outbox if the message bus can't publish it or other
+// operations are still queued
+func (c *Client) sendOrQueue(ctx context.Context, op string, requestTopic string, requestId string, request any,
+	responseTimeout time.Duration, send func(ctx context.Context) ([]byte, errors.EdgeX)) ([]byte, errors.EdgeX) {
+	if c.outbox.empty() {
+		reply, err := send(ctx)
+		if !stdErrors.Is(err, ErrPublishFailed) {
+			return reply, err
+		}
+		c.lc.Warnf("failed to publish XRT request %s, requestId: %s, queueing it: %v", op, requestId, err)
+	}
+
+	data, encodeErr := c.codec.Marshal(request)
+	if encodeErr != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to encode XRT request %s", op), encodeErr)
+	}
+	entry, err := c.outbox.enqueue(op, requestTopic, requestId, c.nodeID, encodedRequest{codec: c.codec, data: data}, responseTimeout, c.callerOf(ctx), retryRequested(ctx))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to queue XRT request %s", op), err)
+	}
+	return nil, errors.NewCommonEdgeX(KindQueued, "", &QueuedError{Id: entry.Id, Op: op})
+}
+
+// replayQueued sends the queued operation like any other request, without queueing it again, and returns the outcome
+func (c *Client) replayQueued(ctx context.Context, entry OutboxEntry) errors.EdgeX {
+	if entry.Codec != c.codec.Name() {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("queued operation %s is encoded with codec %s, but the client uses %s", entry.Id, entry.Codec, c.codec.Name()), nil)
+	}
+	sender := c
+	if entry.NodeID != "" {
+		sender = c.withNode(entry.NodeID, entry.Topic, "")
+	}
+	if entry.Retry {
+		ctx = WithRetry(ctx)
+	}
+	request := encodedRequest{codec: c.codec, data: entry.Request}
+	return sender.sendXrtOp(ctx, entry.Caller, entry.Op, entry.Topic, entry.RequestId, request, nil, entry.Timeout, true)
+}
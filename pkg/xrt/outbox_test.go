@@ -0,0 +1,126 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"bytes"
+	"context"
+	stdErrors "errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// unavailableBus fails to publish on topic while down, and records the requests it published on it
+type unavailableBus struct {
+	*xrttest.MessageBus
+	topic string
+
+	mutex     sync.Mutex
+	down      bool
+	published [][]byte
+}
+
+func (b *unavailableBus) PublishBinaryData(data []byte, topic string) error {
+	if topic == b.topic {
+		b.mutex.Lock()
+		if b.down {
+			b.mutex.Unlock()
+			return stdErrors.New("message bus unavailable")
+		}
+		b.published = append(b.published, data)
+		b.mutex.Unlock()
+	}
+	return b.MessageBus.PublishBinaryData(data, topic)
+}
+
+func (b *unavailableBus) setDown(down bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.down = down
+}
+
+func (b *unavailableBus) Published() [][]byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([][]byte(nil), b.published...)
+}
+
+func TestOutboxReplay(t *testing.T) {
+	bus := &unavailableBus{MessageBus: xrttest.NewMessageBus(), topic: "outbox/command", down: true}
+	node := xrttest.NewXRT(bus.MessageBus, xrttest.Options{RequestTopic: "outbox/request", CommandTopic: "outbox/command", ReplyTopic: "outbox/reply"})
+	node.AddComponent(xrttest.Component{Name: "mqtt-1", Category: "north"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	outbox, err := xrt.NewOutbox(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewOutbox failed: %v", err)
+	}
+	outcomes := make(chan errors.EdgeX, 1)
+	outbox.OnOutcome = func(_ xrt.OutboxEntry, err errors.EdgeX) { outcomes <- err }
+	sink := &recordingSink{}
+	options := xrt.NewClientOptions(xrt.NewCommandOptions("outbox/command", "", nil), nil, nil)
+	options.Outbox = outbox
+	options.AuditLog = xrt.NewAuditLog(sink)
+	options.RetryPolicy = xrt.NewRetryPolicy(2, 10*time.Millisecond, 0)
+	client, err := xrt.NewXrtClient(context.Background(), bus, "outbox/request", "outbox/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+
+	// the value doesn't survive a round trip through float64
+	value := uint64(math.MaxUint64)
+	err = client.UpdateComponent(xrt.WithRetry(context.Background()), "mqtt-1", map[string]any{"MaxMessageId": value})
+	if !stdErrors.Is(err, xrt.ErrQueued) || errors.Kind(err) != xrt.KindQueued {
+		t.Fatalf("UpdateComponent returned %v of kind %s while the message bus is unavailable, want ErrQueued", err, errors.Kind(err))
+	}
+	if xrt.IsRetryableError(err) {
+		t.Fatalf("queued operation %v is retryable, want it told apart from a timeout", err)
+	}
+	if code := err.Code(); code != http.StatusInternalServerError {
+		t.Fatalf("queued operation maps to the HTTP status code %d, want 500 like the other client-specific kinds", code)
+	}
+	if records := sink.Records(); len(records) != 0 {
+		t.Fatalf("audited %+v for the queued operation, want it audited once replayed", records)
+	}
+
+	// the first replayed attempt runs into the response timeout and is retried
+	node.SetFault(xrttest.OpComponentUpdate, xrttest.Fault{Drop: true, Count: 1})
+	bus.setDown(false)
+	outbox.Replay()
+	select {
+	case err = <-outcomes:
+		if err != nil {
+			t.Fatalf("replay failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued operation wasn't replayed")
+	}
+
+	published := bus.Published()
+	if len(published) != 2 {
+		t.Fatalf("published %d requests, want the replay and its retry", len(published))
+	}
+	for _, request := range published {
+		if !bytes.Contains(request, []byte(strconv.FormatUint(value, 10))) {
+			t.Fatalf("replayed request %s lost the precision of the config", request)
+		}
+	}
+	records := sink.Records()
+	if len(records) != 1 || records[0].Outcome != xrt.OutcomeOK || len(records[0].Attempts) != 2 {
+		t.Fatalf("audited %+v, want the replay recorded once with both attempts", records)
+	}
+}
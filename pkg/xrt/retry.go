@@ -0,0 +1,200 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBackoffMultiplier = 2
+	defaultBackoffJitter     = 0.2
+	// requestIdField is the field of the encoded xrtmodels requests holding the RequestId
+	requestIdField = "request_id"
+)
+
+// RetryPolicy decides whether and when a failed XRT request is sent again.
+// The policy applies to the read-only operations, see IsReadOnlyOp; mutating operations are only retried when
+// the call opts in with WithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one, values below 2 disable retrying
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, zero means no cap
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every attempt, defaults to 2
+	Multiplier float64
+	// Jitter randomizes every delay by up to the given fraction, e.g. 0.2 for +/-20%
+	Jitter float64
+	// Retryable decides which errors are worth another attempt, defaults to IsRetryableError
+	Retryable func(err errors.EdgeX) bool
+}
+
+// NewRetryPolicy creates a RetryPolicy with exponential backoff, 20% jitter and the default error classifier
+func NewRetryPolicy(maxAttempts int, initialBackoff time.Duration, maxBackoff time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+		Multiplier:     defaultBackoffMultiplier,
+		Jitter:         defaultBackoffJitter,
+	}
+}
+
+// IsRetryableError reports whether the request may succeed when sent again: the reply didn't arrive in time or the
+// request couldn't be published. Errors reported by XRT, errors caused by the caller's context and requests rejected
+// by an open circuit are final.
+func IsRetryableError(err errors.EdgeX) bool {
+	if err == nil || stdErrors.Is(err, context.Canceled) || stdErrors.Is(err, context.DeadlineExceeded) || stdErrors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	// XRT timing out on its side, e.g. a device not answering, is reported with KindTimeout too
+	var xrtErr *XRTError
+	if stdErrors.As(err, &xrtErr) {
+		return false
+	}
+	kind := errors.Kind(err)
+	return kind == KindTimeout || kind == errors.KindCommunicationError
+}
+
+type retryContextKey struct{}
+
+// WithRetry returns a context which makes the client apply its RetryPolicy to the request even if the operation
+// mutates state. Only use it for requests that are safe to apply more than once.
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, true)
+}
+
+func retryRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(retryContextKey{}).(bool)
+	return requested
+}
+
+func (p *RetryPolicy) retryable(err errors.EdgeX) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryableError(err)
+}
+
+// backoff returns the delay after the given failed attempt, starting from 1
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1) //nolint:gosec // jitter doesn't need a cryptographic random source
+	}
+	return time.Duration(delay)
+}
+
+// retryPolicy returns the policy applying to the request, or nil if the request is sent only once
+func (c *Client) retryPolicy(ctx context.Context, op string) *RetryPolicy {
+	if c.clientOptions == nil || c.clientOptions.RetryPolicy == nil || c.clientOptions.RetryPolicy.MaxAttempts < 2 {
+		return nil
+	}
+	if !IsReadOnlyOp(op) && !retryRequested(ctx) {
+		return nil
+	}
+	return c.clientOptions.RetryPolicy
+}
+
+// sleepContext waits for the given duration unless ctx is done first
+func sleepContext(ctx context.Context, duration time.Duration) errors.EdgeX {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return contextError(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// attemptLog collects the requestIds of the attempts of a request, which change on every retry
+type attemptLog struct {
+	mutex      sync.Mutex
+	requestIds []string
+}
+
+func newAttemptLog(requestId string) *attemptLog {
+	return &attemptLog{requestIds: []string{requestId}}
+}
+
+func (l *attemptLog) add(requestId string) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.requestIds = append(l.requestIds, requestId)
+}
+
+// retried returns the requestIds of all attempts, or nil if the request was sent once
+func (l *attemptLog) retried() []string {
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(l.requestIds) < 2 {
+		return nil
+	}
+	return slices.Clone(l.requestIds)
+}
+
+// withNewRequestId returns a copy of the xrtmodels request with a fresh RequestId, so replies to an earlier attempt
+// can't be mistaken for replies to the new one
+func withNewRequestId(request any) (any, string, errors.EdgeX) {
+	requestId := uuid.NewString()
+	copied, err := withRequestId(request, requestId)
+	if err != nil {
+		return nil, "", errors.NewCommonEdgeXWrapper(err)
+	}
+	return copied, requestId, nil
+}
+
+// withRequestId returns a copy of the xrtmodels request with the given RequestId
+func withRequestId(request any, requestId string) (any, errors.EdgeX) {
+	if encoded, ok := request.(encodedRequest); ok {
+		return encoded.withRequestId(requestId)
+	}
+	value := reflect.ValueOf(request)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("unexpected XRT request type %T", request), nil)
+	}
+
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+	field := copied.FieldByName("RequestId")
+	if !field.IsValid() || field.Kind() != reflect.String || !field.CanSet() {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("XRT request type %T has no RequestId", request), nil)
+	}
+	field.SetString(requestId)
+	return copied.Interface(), nil
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		err       errors.EdgeX
+		retryable bool
+	}{
+		{"timeout", errors.NewCommonEdgeX(xrt.KindTimeout, "", xrt.ErrTimeout), true},
+		{"communication", errors.NewCommonEdgeX(errors.KindCommunicationError, "", xrt.ErrPublishFailed), true},
+		{"cancelled", errors.NewCommonEdgeX(xrt.KindCanceled, "", cancelled.Err()), false},
+		{"circuit open", errors.NewCommonEdgeX(xrt.KindCircuitOpen, "", xrt.ErrCircuitOpen), false},
+		{"XRT timed out", errors.NewCommonEdgeX(xrt.KindTimeout, "", &xrt.XRTError{Status: xrt.StatusTimedOut}), false},
+		{"XRT not found", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "", &xrt.XRTError{Status: xrt.StatusNotFound}), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := xrt.IsRetryableError(tt.err); got != tt.retryable {
+			t.Errorf("IsRetryableError(%s) = %v, want %v", tt.name, got, tt.retryable)
+		}
+	}
+}
+
+type recordingSink struct {
+	mutex   sync.Mutex
+	records []xrt.AuditRecord
+}
+
+func (s *recordingSink) WriteAudit(record xrt.AuditRecord) errors.EdgeX {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *recordingSink) Records() []xrt.AuditRecord {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.records)
+}
+
+func TestRetryRecordsEveryAttempt(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "retry/request", ReplyTopic: "retry/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	sink := &recordingSink{}
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.RetryPolicy = xrt.NewRetryPolicy(3, time.Millisecond, 0)
+	options.AuditLog = xrt.NewAuditLog(sink)
+	client, err := xrt.NewXrtClient(context.Background(), bus, "retry/request", "retry/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+
+	node.SetFault(xrttest.OpProfileAdd, xrttest.Fault{Drop: true, Count: 1})
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(xrt.WithRetry(context.Background()), profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+
+	var sent []string
+	for _, request := range node.Requests() {
+		sent = append(sent, request.RequestId)
+	}
+	records := sink.Records()
+	if len(records) != 1 {
+		t.Fatalf("audited %d records, want 1", len(records))
+	}
+	if len(sent) != 2 || !slices.Equal(records[0].Attempts, sent) || records[0].RequestId != sent[0] {
+		t.Fatalf("audited attempts %v of request %s, node received %v", records[0].Attempts, records[0].RequestId, sent)
+	}
+}
+
+func TestXRTTimeoutNotRetried(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: "noretry/request", ReplyTopic: "noretry/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	defer node.Stop()
+
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.RetryPolicy = xrt.NewRetryPolicy(3, time.Millisecond, 0)
+	client, err := xrt.NewXrtClient(context.Background(), bus, "noretry/request", "noretry/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	defer client.Close()
+
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Status: xrt.StatusTimedOut})
+	if _, err = client.AllDevices(context.Background()); !stdErrors.Is(err, xrt.ErrTimeout) {
+		t.Fatalf("AllDevices returned %v, want the XRT timeout", err)
+	}
+	if received := len(node.Requests()); received != 1 {
+		t.Fatalf("node received %d requests, want 1 as XRT results are final", received)
+	}
+}
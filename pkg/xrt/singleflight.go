@@ -0,0 +1,91 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// flightGroup tracks the XRT requests in flight which identical read requests can join
+type flightGroup struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a shared XRT request, it is cancelled once all of its waiters have given up
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	reply   []byte
+	err     errors.EdgeX
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do joins the flight of the key, or starts it with send if there is none, and waits for the reply unless ctx is
+// done first. The request is sent with the values of the context which started the flight but is only cancelled
+// once every waiter has given up.
+func (g *flightGroup) do(ctx context.Context, key string, send func(ctx context.Context) ([]byte, errors.EdgeX)) ([]byte, errors.EdgeX) {
+	g.mutex.Lock()
+	f, ok := g.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+		go func() {
+			f.reply, f.err = send(flightCtx)
+			g.forget(key, f)
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mutex.Unlock()
+
+	select {
+	case <-f.done:
+		return f.reply, f.err
+	case <-ctx.Done():
+		g.mutex.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mutex.Unlock()
+		return nil, contextError(ctx)
+	}
+}
+
+// forget removes the flight so later requests start a new one
+func (g *flightGroup) forget(key string, f *flight) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
+
+// sendCollapsed sends the read request through the flight of identical requests, which are requests of the same op
+// and topic that only differ in their requestId
+func (c *Client) sendCollapsed(ctx context.Context, op string, requestTopic string, request any,
+	send func(ctx context.Context) ([]byte, errors.EdgeX)) ([]byte, errors.EdgeX) {
+	anonymous, err := withRequestId(request, "")
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	data, marshalErr := c.codec.Marshal(anonymous)
+	if marshalErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(marshalErr)
+	}
+	key := op + "\x00" + requestTopic + "\x00" + string(data)
+	return c.flights.do(ctx, key, send)
+}
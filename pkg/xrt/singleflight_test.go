@@ -0,0 +1,128 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// newCollapsingClient returns a client with CollapseReads enabled and the fake node it talks to, the node holds the
+// device "device"
+func newCollapsingClient(t *testing.T, prefix string) (*xrt.Client, *xrttest.XRT) {
+	t.Helper()
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: prefix + "/request", ReplyTopic: prefix + "/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	t.Cleanup(node.Stop)
+
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.CollapseReads = true
+	client, err := xrt.NewXrtClient(context.Background(), bus, prefix+"/request", prefix+"/reply", time.Second, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err = client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err = client.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	return client.(*xrt.Client), node
+}
+
+// sentOps returns the number of requests of the op received by the node
+func sentOps(node *xrttest.XRT, op string) int {
+	sent := 0
+	for _, request := range node.Requests() {
+		if request.Op == op {
+			sent++
+		}
+	}
+	return sent
+}
+
+func TestCollapseIdenticalReads(t *testing.T) {
+	client, node := newCollapsingClient(t, "collapse")
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Delay: 100 * time.Millisecond, Count: 1})
+
+	var wg sync.WaitGroup
+	results := make([][]string, 5)
+	errs := make([]errors.EdgeX, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.AllDevices(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil || !slices.Equal(results[i], []string{"device"}) {
+			t.Fatalf("AllDevices %d returned %v, %v", i, results[i], errs[i])
+		}
+	}
+	if sent := sentOps(node, xrttest.OpDeviceList); sent != 1 {
+		t.Fatalf("node received %d device:list requests, want the identical reads collapsed into 1", sent)
+	}
+
+	// reads of different entities aren't identical
+	for _, name := range []string{"device", "missing"} {
+		_, _ = client.DeviceByName(context.Background(), name)
+	}
+	if sent := sentOps(node, xrttest.OpDeviceGet); sent != 2 {
+		t.Fatalf("node received %d device:get requests for different devices, want 2", sent)
+	}
+}
+
+func TestCollapsedReadSurvivesCancelledWaiter(t *testing.T) {
+	client, node := newCollapsingClient(t, "collapsecancel")
+	node.SetFault(xrttest.OpDeviceGet, xrttest.Fault{Delay: 100 * time.Millisecond, Count: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan errors.EdgeX, 1)
+	go func() {
+		_, err := client.DeviceByName(ctx, "device")
+		cancelled <- err
+	}()
+	// let the cancelled waiter start the flight
+	time.Sleep(20 * time.Millisecond)
+	waiting := make(chan errors.EdgeX, 1)
+	go func() {
+		device, err := client.DeviceByName(context.Background(), "device")
+		if err == nil && device.ProfileName != "profile" {
+			err = errors.NewCommonEdgeX(errors.KindServerError, "unexpected profile "+device.ProfileName, nil)
+		}
+		waiting <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-cancelled; errors.Kind(err) != xrt.KindCanceled || !stdErrors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled DeviceByName returned %v of kind %s, want the cancellation", err, errors.Kind(err))
+	}
+	if err := <-waiting; err != nil {
+		t.Fatalf("DeviceByName failed after another waiter of the flight was cancelled: %v", err)
+	}
+	if sent := sentOps(node, xrttest.OpDeviceGet); sent != 1 {
+		t.Fatalf("node received %d device:get requests, want 1 shared by both waiters", sent)
+	}
+}
@@ -75,6 +75,7 @@ func (dtm *DispatcherTopicManager) createMessageDispatcher() MessageHandler {
 				defer func() {
 					if r := recover(); r != nil {
 						dtm.lc.Errorf("panic in handler for topic %s: %v", dtm.Topic, r)
+						dtm.notify(func(observer Observer) { observer.HandlerPanicked(dtm.Topic) })
 					}
 				}()
 				h(message)
@@ -82,3 +83,14 @@ func (dtm *DispatcherTopicManager) createMessageDispatcher() MessageHandler {
 		}
 	}
 }
+
+func (dtm *DispatcherTopicManager) stats() ManagerStats {
+	dtm.mutex.RLock()
+	defer dtm.mutex.RUnlock()
+	return ManagerStats{
+		Topic:    dtm.Topic,
+		Type:     ManagerTypeDispatcher,
+		RefCount: dtm.refCount,
+		Handlers: len(dtm.handlerMap),
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package topicmgr
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// Observer is notified of events on the shared topic subscriptions, e.g. to count them as metrics. Register it with
+// the topic managers to observe, see RegisterObserver. Its methods are called from the subscription goroutines and
+// must not block.
+type Observer interface {
+	// ReplyUnmatched is called for a reply whose requestId is unknown, usually a late reply to a timed out request
+	ReplyUnmatched(topic string)
+	// ReplyDropped is called for a reply which couldn't be handed to the waiting request
+	ReplyDropped(topic string)
+	// HandlerPanicked is called when a message handler panicked
+	HandlerPanicked(topic string)
+}
+
+// ObserverID is an opaque identifier returned by RegisterObserver, used to unregister the observer later.
+type ObserverID uint64
+
+// observers holds the Observers registered with a topic manager
+type observers struct {
+	mutex     sync.RWMutex
+	observers map[ObserverID]Observer
+	nextID    ObserverID
+}
+
+// RegisterObserver registers an Observer of the topic and returns an ObserverID for later unregistration. An observer
+// registered several times, e.g. by clients sharing the topic, is notified once per event.
+func (o *observers) RegisterObserver(observer Observer) (ObserverID, errors.EdgeX) {
+	if observer == nil {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "observer must not be nil", nil)
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.observers == nil {
+		o.observers = make(map[ObserverID]Observer)
+	}
+	o.nextID++
+	o.observers[o.nextID] = observer
+	return o.nextID, nil
+}
+
+// UnregisterObserver unregisters an Observer of the topic by its ObserverID
+func (o *observers) UnregisterObserver(id ObserverID) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	delete(o.observers, id)
+}
+
+// notify passes each registered Observer to the given func
+func (o *observers) notify(f func(observer Observer)) {
+	o.mutex.RLock()
+	notified := make([]Observer, 0, len(o.observers))
+	for _, observer := range o.observers {
+		if !slices.ContainsFunc(notified, func(other Observer) bool { return sameObserver(observer, other) }) {
+			notified = append(notified, observer)
+		}
+	}
+	o.mutex.RUnlock()
+
+	for _, observer := range notified {
+		f(observer)
+	}
+}
+
+func sameObserver(a Observer, b Observer) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+// Manager types reported in ManagerStats
+const (
+	ManagerTypeReply      = "reply"
+	ManagerTypeDispatcher = "dispatcher"
+)
+
+// ManagerStats describes a topic manager held by the pool
+type ManagerStats struct {
+	Topic string
+	// Type is ManagerTypeReply or ManagerTypeDispatcher
+	Type string
+	// RefCount is the number of clients sharing the manager
+	RefCount int
+	// PendingRequests is the number of requests awaiting their reply, reply managers only
+	PendingRequests int
+	// Handlers is the number of registered handlers, dispatcher managers only
+	Handlers int
+}
+
+// Stats returns the stats of the managers held by the pool, sorted by topic
+func (pool *TopicManagerPool) Stats() []ManagerStats {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+	stats := make([]ManagerStats, 0, len(pool.managers))
+	for _, manager := range pool.managers {
+		stats = append(stats, manager.stats())
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Topic < stats[j].Topic
+	})
+	return stats
+}
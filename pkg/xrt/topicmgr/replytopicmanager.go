@@ -5,6 +5,8 @@ package topicmgr
 import (
 	"context"
 
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
 	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
@@ -12,7 +14,7 @@ import (
 	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
 )
 
-func commandReplyHandler(requestMap RequestMap, lc logger.LoggingClient) MessageHandler {
+func commandReplyHandler(requestMap RequestMap, replyCodec codec.Codec, observers *observers, lc logger.LoggingClient) MessageHandler {
 	return func(message types.MessageEnvelope) {
 		err := message.ConvertMsgPayloadToByteArray()
 		if err != nil {
@@ -20,7 +22,7 @@ func commandReplyHandler(requestMap RequestMap, lc logger.LoggingClient) Message
 			return
 		}
 		var response xrtmodels.BaseResponse
-		response, err = types.GetMsgPayload[xrtmodels.BaseResponse](message)
+		err = replyCodec.Unmarshal(message.Payload.([]byte), &response)
 		if err != nil {
 			lc.Warnf("failed to parse XRT reply, message:%s, err: %v", message.Payload, err)
 			return
@@ -28,6 +30,7 @@ func commandReplyHandler(requestMap RequestMap, lc logger.LoggingClient) Message
 		resChan, ok := requestMap.Get(response.RequestId)
 		if !ok {
 			lc.Debugf("deprecated response from the XRT, it might be caused by timeout or unknown error, topic: %s, message:%s", message.ReceivedTopic, message.Payload)
+			observers.notify(func(observer Observer) { observer.ReplyUnmatched(message.ReceivedTopic) })
 			return
 		}
 
@@ -35,6 +38,7 @@ func commandReplyHandler(requestMap RequestMap, lc logger.LoggingClient) Message
 		case resChan <- message.Payload.([]byte):
 		default:
 			lc.Debugf("dropping XRT reply because reply channel is not ready (no receiver waiting or buffer full), requestId: %s, topic: %s", response.RequestId, message.ReceivedTopic)
+			observers.notify(func(observer Observer) { observer.ReplyDropped(message.ReceivedTopic) })
 		}
 	}
 }
@@ -43,16 +47,35 @@ func commandReplyHandler(requestMap RequestMap, lc logger.LoggingClient) Message
 type ReplyTopicManager struct {
 	topicManagerBase
 	RequestMap RequestMap
+	codec      codec.Codec
 }
 
-func newReplyTopicManager(topic string, messageBus messaging.MessageClient, lc logger.LoggingClient, cancelFunc context.CancelFunc) *ReplyTopicManager {
+func newReplyTopicManager(topic string, messageBus messaging.MessageClient, replyCodec codec.Codec, lc logger.LoggingClient, cancelFunc context.CancelFunc) *ReplyTopicManager {
 	return &ReplyTopicManager{
 		topicManagerBase: newTopicManagerBase(topic, messageBus, lc, cancelFunc),
 		RequestMap:       NewRequestMap(),
+		codec:            replyCodec,
 	}
 }
 
+// Codec returns the codec the replies on the topic are decoded with
+func (rtm *ReplyTopicManager) Codec() codec.Codec {
+	return rtm.codec
+}
+
 func (rtm *ReplyTopicManager) subscribe(subscriptionCtx context.Context) errors.EdgeX {
-	handler := commandReplyHandler(rtm.RequestMap, rtm.lc)
+	handler := commandReplyHandler(rtm.RequestMap, rtm.codec, &rtm.observers, rtm.lc)
 	return rtm.startListening(subscriptionCtx, handler)
 }
+
+func (rtm *ReplyTopicManager) stats() ManagerStats {
+	stats := ManagerStats{
+		Topic:    rtm.Topic,
+		Type:     ManagerTypeReply,
+		RefCount: rtm.refCount,
+	}
+	if requestMap, ok := rtm.RequestMap.(*requestMap); ok {
+		stats.PendingRequests = requestMap.len()
+	}
+	return stats
+}
@@ -41,3 +41,10 @@ func (m *requestMap) Delete(id string) {
 	defer m.mutex.Unlock()
 	delete(m.responseChanMap, id)
 }
+
+// len returns the number of requests awaiting their reply
+func (m *requestMap) len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return len(m.responseChanMap)
+}
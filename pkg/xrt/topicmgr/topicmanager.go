@@ -4,6 +4,7 @@ package topicmgr
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
@@ -20,6 +21,7 @@ type topicManager interface {
 	incrementRefCount()
 	decrementRefCount() int
 	shutdown()
+	stats() ManagerStats
 }
 
 // topicManagerBase provides common fields and functionality shared by all topic managers
@@ -31,6 +33,8 @@ type topicManagerBase struct {
 	messageErrors chan error
 	topicChannel  types.TopicChannel
 	refCount      int
+	active        atomic.Bool
+	observers
 }
 
 func newTopicManagerBase(topic string, messageBus messaging.MessageClient, lc logger.LoggingClient, cancelFunc context.CancelFunc) topicManagerBase {
@@ -57,7 +61,13 @@ func (b *topicManagerBase) decrementRefCount() int {
 	return b.refCount
 }
 
+// Active reports whether the topic is subscribed, from a successful subscription until the manager is released
+func (b *topicManagerBase) Active() bool {
+	return b.active.Load()
+}
+
 func (b *topicManagerBase) shutdown() {
+	b.active.Store(false)
 	b.cancelFunc()
 	err := b.messageBus.Unsubscribe(b.Topic)
 	if err != nil {
@@ -84,6 +94,7 @@ func (b *topicManagerBase) startListening(subscriptionCtx context.Context, handl
 					defer func() {
 						if recovered := recover(); recovered != nil {
 							b.lc.Errorf("panic while handling message from topic '%s': %v", b.topicChannel.Topic, recovered)
+							b.notify(func(observer Observer) { observer.HandlerPanicked(b.topicChannel.Topic) })
 						}
 					}()
 					handler(message)
@@ -97,6 +108,7 @@ func (b *topicManagerBase) startListening(subscriptionCtx context.Context, handl
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to subscribe to topic", err)
 	}
 
+	b.active.Store(true)
 	b.lc.Debugf("Subscribed to %s", b.topicChannel.Topic)
 	return nil
 }
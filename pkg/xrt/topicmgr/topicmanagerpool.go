@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
@@ -23,15 +25,26 @@ var TmPool = &TopicManagerPool{
 	managers: make(map[string]topicManager),
 }
 
-// GetReplyTopicManager returns an existing ReplyTopicManager for the given topic, or creates a new one.
+// GetReplyTopicManager returns an existing ReplyTopicManager for the given topic, or creates a new one decoding JSON replies.
 func (pool *TopicManagerPool) GetReplyTopicManager(
 	topic string,
 	messageBus messaging.MessageClient,
 	lc logger.LoggingClient) (*ReplyTopicManager, errors.EdgeX) {
+	return pool.GetReplyTopicManagerWithCodec(topic, messageBus, codec.JSON, lc)
+}
+
+// GetReplyTopicManagerWithCodec returns an existing ReplyTopicManager for the given topic, or creates a new one decoding
+// replies with the given codec. All clients sharing a reply topic must use the same codec.
+func (pool *TopicManagerPool) GetReplyTopicManagerWithCodec(
+	topic string,
+	messageBus messaging.MessageClient,
+	replyCodec codec.Codec,
+	lc logger.LoggingClient) (*ReplyTopicManager, errors.EdgeX) {
 
 	if topic == "" {
 		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "topic cannot be empty", nil)
 	}
+	replyCodec = codec.OrDefault(replyCodec)
 
 	pool.mutex.Lock()
 	defer pool.mutex.Unlock()
@@ -42,12 +55,16 @@ func (pool *TopicManagerPool) GetReplyTopicManager(
 			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid,
 				fmt.Sprintf("topic '%s' is already subscribed with a different topic manager", topic), nil)
 		}
+		if rtm.codec.Name() != replyCodec.Name() {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("topic '%s' is already subscribed with the %s codec", topic, rtm.codec.Name()), nil)
+		}
 		existing.incrementRefCount()
 		return rtm, nil
 	}
 
 	subscriptionCtx, cancelFunc := context.WithCancel(context.Background())
-	manager := newReplyTopicManager(topic, messageBus, lc, cancelFunc)
+	manager := newReplyTopicManager(topic, messageBus, replyCodec, lc, cancelFunc)
 
 	if err := manager.subscribe(subscriptionCtx); err != nil {
 		cancelFunc()
@@ -0,0 +1,179 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// Tracer starts a span per XRT op. It mirrors the subset of the OpenTelemetry tracing API used by the client, so
+// adapting an OpenTelemetry tracer takes a few lines.
+type Tracer interface {
+	// Start starts a span named after the op as a child of the span in ctx, if any, and returns a context holding it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	SetAttributes(attributes map[string]any)
+	AddEvent(name string, attributes map[string]any)
+	RecordError(err error)
+	End()
+	// TraceParent returns the W3C traceparent header value of the span, or an empty string to skip the propagation
+	TraceParent() string
+}
+
+// Attribute keys and event names of the spans started by the client
+const (
+	AttributeOp        = "xrt.op"
+	AttributeRequestId = "xrt.request_id"
+	AttributeTopic     = "xrt.topic"
+	AttributeOutcome   = "xrt.outcome"
+	AttributeStatus    = "xrt.status"
+	AttributeAttempt   = "xrt.attempt"
+	// AttributeTargetPrefix prefixes the entities the request targets, e.g. xrt.device or xrt.profile
+	AttributeTargetPrefix = "xrt."
+
+	EventReply = "xrt.reply"
+	// EventRetry is added before every retry with the number and requestId of the new attempt and the error of the
+	// previous one
+	EventRetry = "xrt.retry"
+
+	// OutcomeOK is the outcome of succeeded requests, failed requests report their EdgeX error kind
+	OutcomeOK = "ok"
+)
+
+// TraceParentField is the top-level field of the request payload carrying the W3C traceparent of the span. It isn't
+// part of the XRT request models, so it is only added when the span returns a traceparent, which NoopTracer never
+// does; use spans returning an empty TraceParent with XRT nodes rejecting unknown fields.
+const TraceParentField = "traceparent"
+
+// NoopTracer is the default Tracer, its spans record nothing
+type NoopTracer struct{}
+
+func (NoopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(map[string]any)    {}
+func (noopSpan) AddEvent(string, map[string]any) {}
+func (noopSpan) RecordError(error)               {}
+func (noopSpan) End()                            {}
+func (noopSpan) TraceParent() string             { return "" }
+
+type spanContextKey struct{}
+
+// spanFromContext returns the span of the XRT request in ctx, or a no-op span
+func spanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanContextKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+// startSpan starts the span of an XRT op and returns a context holding it
+func (c *Client) startSpan(ctx context.Context, op string, requestTopic string, requestId string, request any) (context.Context, Span) {
+	if c.clientOptions == nil || c.clientOptions.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := c.clientOptions.Tracer.Start(ctx, op)
+	attributes := map[string]any{
+		AttributeOp:        op,
+		AttributeRequestId: requestId,
+		AttributeTopic:     requestTopic,
+	}
+	for kind, name := range requestTargets(request) {
+		attributes[AttributeTargetPrefix+kind] = name
+	}
+	span.SetAttributes(attributes)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// endSpan records the outcome of the XRT op and ends its span
+func endSpan(span Span, err errors.EdgeX) {
+	if err == nil {
+		span.SetAttributes(map[string]any{AttributeOutcome: OutcomeOK})
+		span.End()
+		return
+	}
+	attributes := map[string]any{AttributeOutcome: string(errors.Kind(err))}
+	var xrtErr *XRTError
+	if stdErrors.As(err, &xrtErr) {
+		attributes[AttributeStatus] = xrtErr.Status
+	}
+	span.SetAttributes(attributes)
+	span.RecordError(err)
+	span.End()
+}
+
+// injectTraceParent adds the traceparent of the span in ctx to the encoded request, the request is returned
+// unchanged if there is no traceparent, it isn't an object or the codec isn't a codec.FieldSetter
+func (c *Client) injectTraceParent(ctx context.Context, data []byte) []byte {
+	traceParent := spanFromContext(ctx).TraceParent()
+	if traceParent == "" {
+		return data
+	}
+	setter, ok := c.codec.(codec.FieldSetter)
+	if !ok {
+		return data
+	}
+	injected, err := setter.SetField(data, TraceParentField, traceParent)
+	if err != nil {
+		c.lc.Debugf("failed to inject the traceparent into the XRT request: %v", err)
+		return data
+	}
+	return injected
+}
+
+// targetFields are the request fields naming the entities targeted by an XRT request
+var targetFields = []string{"device", "profile", "schedule", "component"}
+
+// requestFields returns the JSON fields of the xrtmodels request, or nil if it doesn't encode to an object
+func requestFields(request any) map[string]any {
+	var fields map[string]any
+	if encoded, ok := request.(encodedRequest); ok {
+		if err := encoded.codec.Unmarshal(encoded.data, &fields); err != nil {
+			return nil
+		}
+		return fields
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// requestTargets returns the names of the entities targeted by the xrtmodels request, keyed by entity kind. Entity
+// fields hold either the name or the entity object itself.
+func requestTargets(request any) map[string]string {
+	return targetsOf(requestFields(request))
+}
+
+func targetsOf(fields map[string]any) map[string]string {
+	targets := make(map[string]string)
+	for _, field := range targetFields {
+		switch value := fields[field].(type) {
+		case string:
+			if value != "" {
+				targets[field] = value
+			}
+		case map[string]any:
+			if name, ok := value["name"].(string); ok && name != "" {
+				targets[field] = name
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrt_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// recordingTracer records the spans it starts, they all return testTraceParent
+type recordingTracer struct {
+	mutex sync.Mutex
+	spans []*recordingSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string) (context.Context, xrt.Span) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	span := &recordingSpan{name: name, attributes: make(map[string]any)}
+	r.spans = append(r.spans, span)
+	return ctx, span
+}
+
+// Spans returns the spans named after the op
+func (r *recordingTracer) Spans(op string) []*recordingSpan {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var spans []*recordingSpan
+	for _, span := range r.spans {
+		if span.name == op {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+type recordedEvent struct {
+	name       string
+	attributes map[string]any
+}
+
+type recordingSpan struct {
+	name string
+
+	mutex      sync.Mutex
+	attributes map[string]any
+	events     []recordedEvent
+	errs       []error
+	ended      bool
+}
+
+func (s *recordingSpan) SetAttributes(attributes map[string]any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for k, v := range attributes {
+		s.attributes[k] = v
+	}
+}
+
+func (s *recordingSpan) AddEvent(name string, attributes map[string]any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.events = append(s.events, recordedEvent{name: name, attributes: attributes})
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordingSpan) End() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ended = true
+}
+
+func (s *recordingSpan) TraceParent() string {
+	return testTraceParent
+}
+
+func (s *recordingSpan) Attribute(key string) any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.attributes[key]
+}
+
+// Events returns the events with the given name
+func (s *recordingSpan) Events(name string) []recordedEvent {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var events []recordedEvent
+	for _, event := range s.events {
+		if event.name == name {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// newTracedClient returns a client recording its spans with the tracer and the fake node it talks to
+func newTracedClient(t *testing.T, prefix string, payloadCodec codec.Codec, tracer xrt.Tracer) (*xrt.Client, *xrttest.XRT) {
+	t.Helper()
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: prefix + "/request", ReplyTopic: prefix + "/reply", Codec: payloadCodec})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	t.Cleanup(node.Stop)
+
+	options := xrt.NewClientOptions(nil, nil, nil)
+	options.Codec = payloadCodec
+	options.Tracer = tracer
+	options.RetryPolicy = xrt.NewRetryPolicy(2, time.Millisecond, 0)
+	client, err := xrt.NewXrtClient(context.Background(), bus, prefix+"/request", prefix+"/reply", 100*time.Millisecond, logger.NewMockClient(), options)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client.(*xrt.Client), node
+}
+
+func TestTracingSpans(t *testing.T) {
+	tracer := &recordingTracer{}
+	client, node := newTracedClient(t, "tracing", nil, tracer)
+	ctx := context.Background()
+
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err := client.AddDeviceProfile(ctx, profile); err != nil {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err := client.AddDevice(ctx, dtos.Device{Name: "device", ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+	spans := tracer.Spans(xrt.OpDeviceAdd)
+	if len(spans) != 1 {
+		t.Fatalf("started %d %s spans, want 1", len(spans), xrt.OpDeviceAdd)
+	}
+	span := spans[0]
+	requests := node.Requests()
+	want := map[string]any{
+		xrt.AttributeOp:                      xrt.OpDeviceAdd,
+		xrt.AttributeRequestId:               requests[len(requests)-1].RequestId,
+		xrt.AttributeTopic:                   "tracing/request",
+		xrt.AttributeTargetPrefix + "device": "device",
+		xrt.AttributeOutcome:                 xrt.OutcomeOK,
+	}
+	for key, value := range want {
+		if got := span.Attribute(key); got != value {
+			t.Errorf("span attribute %s is %v, want %v", key, got, value)
+		}
+	}
+	if !span.ended || len(span.errs) != 0 || len(span.Events(xrt.EventReply)) != 1 {
+		t.Fatalf("span of the succeeded op is ended %v with errors %v and events %v", span.ended, span.errs, span.events)
+	}
+
+	if _, err := client.DeviceByName(ctx, "missing"); !stdErrors.Is(err, xrt.ErrNotFound) {
+		t.Fatalf("DeviceByName returned %v, want ErrNotFound", err)
+	}
+	span = tracer.Spans(xrt.OpDeviceGet)[0]
+	if span.Attribute(xrt.AttributeOutcome) != string(errors.KindEntityDoesNotExist) || span.Attribute(xrt.AttributeStatus) != xrt.StatusNotFound {
+		t.Fatalf("span of the failed op has the outcome %v and status %v, want %s and %d", span.Attribute(xrt.AttributeOutcome),
+			span.Attribute(xrt.AttributeStatus), errors.KindEntityDoesNotExist, xrt.StatusNotFound)
+	}
+	if !span.ended || len(span.errs) != 1 || !stdErrors.Is(span.errs[0], xrt.ErrNotFound) {
+		t.Fatalf("span of the failed op is ended %v with errors %v, want the error recorded", span.ended, span.errs)
+	}
+}
+
+func TestTracingRetryEvent(t *testing.T) {
+	tracer := &recordingTracer{}
+	client, node := newTracedClient(t, "tracingretry", nil, tracer)
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true, Count: 1})
+
+	if _, err := client.AllDevices(xrt.WithRetry(context.Background())); err != nil {
+		t.Fatalf("AllDevices failed: %v", err)
+	}
+	spans := tracer.Spans(xrt.OpDeviceList)
+	if len(spans) != 1 {
+		t.Fatalf("started %d spans for the retried op, want 1", len(spans))
+	}
+	retries := spans[0].Events(xrt.EventRetry)
+	requests := node.Requests()
+	if len(retries) != 1 || len(requests) != 2 {
+		t.Fatalf("span has the retry events %v for the requests %+v, want 1 retry", retries, requests)
+	}
+	attributes := retries[0].attributes
+	if attributes[xrt.AttributeAttempt] != 2 || attributes[xrt.AttributeRequestId] != requests[1].RequestId {
+		t.Fatalf("retry event has the attributes %v, want attempt 2 with the requestId %s", attributes, requests[1].RequestId)
+	}
+	if attributes["error"] == nil || spans[0].Attribute(xrt.AttributeOutcome) != xrt.OutcomeOK {
+		t.Fatalf("retry event has the attributes %v and the span the outcome %v, want the timeout of the first attempt and ok",
+			attributes, spans[0].Attribute(xrt.AttributeOutcome))
+	}
+}
+
+func TestTraceParentInjected(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec codec.Codec
+	}{
+		{"JSON", codec.JSON},
+		{"CBOR", codec.CBOR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, node := newTracedClient(t, "traceparent/"+tt.name, tt.codec, &recordingTracer{})
+			if _, err := client.AllDevices(context.Background()); err != nil {
+				t.Fatalf("AllDevices failed: %v", err)
+			}
+			requests := node.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("node received %d requests, want 1", len(requests))
+			}
+			var fields map[string]any
+			if err := tt.codec.Unmarshal(requests[0].Payload, &fields); err != nil {
+				t.Fatalf("failed to decode the request: %v", err)
+			}
+			if fields[xrt.TraceParentField] != testTraceParent || fields["op"] != xrt.OpDeviceList {
+				t.Fatalf("node received %v, want the request with the traceparent of the span", fields)
+			}
+		})
+	}
+}
@@ -4,10 +4,12 @@ package xrt
 
 import (
 	"context"
-	"encoding/json"
+	stdErrors "errors"
+	"fmt"
 	"time"
 
 	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/interfaces"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
 	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/topicmgr"
 
 	"github.com/IOTechSystems/go-mod-central-ext/v4/pkg/xrtmodels"
@@ -27,6 +29,16 @@ type Client struct {
 	requestTopic    string
 	replyTopic      string
 	responseTimeout time.Duration
+	codec           codec.Codec
+	flights         *flightGroup
+	limiter         *limiter
+	breaker         *breaker
+	outbox          *Outbox
+	health          *healthState
+	detected        *detectedNodes
+	// nodeID and nodeCommandTopic are set on node views, see ForNode
+	nodeID           string
+	nodeCommandTopic string
 
 	clientOptions *ClientOptions
 
@@ -37,12 +49,58 @@ type Client struct {
 	discoveryHandlerID           topicmgr.HandlerID
 	statusTopicManager           *topicmgr.DispatcherTopicManager
 	statusHandlerID              topicmgr.HandlerID
+	// observations are the registrations of the Metrics with the topic managers, see observeTopic
+	observations []topicObservation
+}
+
+// observedTopicManager is a topic manager an Observer can be registered with
+type observedTopicManager interface {
+	RegisterObserver(observer topicmgr.Observer) (topicmgr.ObserverID, errors.EdgeX)
+	UnregisterObserver(id topicmgr.ObserverID)
+}
+
+type topicObservation struct {
+	manager observedTopicManager
+	id      topicmgr.ObserverID
 }
 
 type ClientOptions struct {
 	*CommandOptions
 	*DiscoveryOptions
 	*StatusOptions
+
+	// RetryPolicy resends read-only requests, and requests opted in with WithRetry, when they fail. Nil disables retrying.
+	RetryPolicy *RetryPolicy
+	// Interceptors wrap every XRT request sent by the client, the first one is the outermost. Retried requests pass
+	// the interceptors once per attempt.
+	Interceptors []Interceptor
+	// Codec encodes the requests and decodes the replies, XRT must be configured with the same encoding. Defaults to
+	// codec.JSON; clients sharing a reply topic must use the same codec.
+	Codec codec.Codec
+	// CollapseReads makes concurrent identical read-only requests share a single XRT request and its reply
+	CollapseReads bool
+	// Limits caps the requests in flight and the request rate, nil disables limiting
+	Limits *Limits
+	// CircuitBreaker fails requests fast while the XRT node of their request topic doesn't reply, nil disables it
+	CircuitBreaker *CircuitBreaker
+	// Metrics records every request attempt, nil disables recording. If it implements topicmgr.Observer too, it also
+	// observes the topic subscriptions of the client.
+	Metrics MetricsRecorder
+	// Tracer starts a span per XRT op and propagates its traceparent to XRT in the TraceParentField of the requests, nil
+	// disables tracing. Return an empty TraceParent from the spans to trace without adding the field.
+	Tracer Tracer
+	// AuditLog records every mutating operation, nil disables auditing
+	AuditLog *AuditLog
+	// DryRun records the requests instead of publishing them, nil disables the dry-run mode
+	DryRun *DryRunInspector
+	// Outbox queues the mutating operations which can't be published, nil makes them fail instead
+	Outbox *Outbox
+	// Nodes provides the topics of individual XRT nodes, required by ForNode
+	Nodes *NodeOptions
+	// Readiness provides the config of Ping and WaitReady, nil pings XRT without checking the node status
+	Readiness *ReadinessOptions
+	// Capabilities enables the version negotiation with the XRT nodes, nil sends every request in the 2.2 format
+	Capabilities *CapabilityOptions
 }
 
 // CommandOptions provides the config for sending the request to manage components
@@ -79,17 +137,33 @@ func NewXrtClient(_ context.Context, messageBus messaging.MessageClient, request
 		requestTopic:    requestTopic,
 		replyTopic:      replyTopic,
 		responseTimeout: responseTimeout,
+		codec:           codec.JSON,
+		health:          &healthState{},
+		detected:        &detectedNodes{nodes: make(map[string]Capabilities)},
 		clientOptions:   clientOptions,
 	}
+	if clientOptions != nil {
+		client.codec = codec.OrDefault(clientOptions.Codec)
+		if clientOptions.CollapseReads {
+			client.flights = newFlightGroup()
+		}
+		if clientOptions.Limits != nil {
+			client.limiter = newLimiter(*clientOptions.Limits)
+		}
+		if clientOptions.CircuitBreaker != nil {
+			client.breaker = newBreaker(*clientOptions.CircuitBreaker)
+		}
+	}
 
 	// Initialize ReplyTopic subscription
 	if replyTopic != "" {
 		var err errors.EdgeX
-		replyManager, err := topicmgr.TmPool.GetReplyTopicManager(replyTopic, messageBus, lc)
+		replyManager, err := topicmgr.TmPool.GetReplyTopicManagerWithCodec(replyTopic, messageBus, client.codec, lc)
 		if err != nil {
 			return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to init subscriptions", err)
 		}
 		client.replyTopicManager = replyManager
+		client.observeTopic(replyManager)
 	}
 
 	// Initialize subscriptions for other topics defined in clientOptions
@@ -106,6 +180,15 @@ func NewXrtClient(_ context.Context, messageBus messaging.MessageClient, request
 			_ = client.Close()
 			return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to init status subscription", err)
 		}
+		if clientOptions.Outbox != nil {
+			// set before attaching, as the outbox starts replaying with the client right away
+			client.outbox = clientOptions.Outbox
+			if err := clientOptions.Outbox.attach(client); err != nil {
+				client.outbox = nil
+				_ = client.Close()
+				return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to attach the outbox", err)
+			}
+		}
 	}
 
 	return client, nil
@@ -149,79 +232,279 @@ func (c *Client) SetResponseTimeout(responseTimeout time.Duration) {
 }
 
 // sendXrtRequest sends general request to XRT
-func (c *Client) sendXrtRequest(ctx context.Context, requestId string, request interface{}, response interface{}) errors.EdgeX {
-	return c.sendXrtRequestWithTimeout(ctx, c.requestTopic, requestId, request, response, c.responseTimeout)
+func (c *Client) sendXrtRequest(ctx context.Context, op string, requestId string, request interface{}, response interface{}) errors.EdgeX {
+	return c.sendXrtRequestWithTimeout(ctx, op, c.requestTopic, requestId, request, response, c.responseTimeout)
 }
 
 // sendXrtDiscoveryRequest sends discovery request to XRT
-func (c *Client) sendXrtDiscoveryRequest(ctx context.Context, requestId string, request interface{}, response interface{}) errors.EdgeX {
+func (c *Client) sendXrtDiscoveryRequest(ctx context.Context, op string, requestId string, request interface{}, response interface{}) errors.EdgeX {
 	if c.clientOptions == nil || c.clientOptions.DiscoveryOptions == nil {
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "please provide DiscoveryOptions for the discovery request", nil)
 	}
 	timeout := time.Duration(c.responseTimeout.Nanoseconds() + c.clientOptions.DiscoveryTimeout.Nanoseconds())
-	return c.sendXrtRequestWithTimeout(ctx, c.requestTopic, requestId, request, response, timeout)
+	return c.sendXrtRequestWithTimeout(ctx, op, c.requestTopic, requestId, request, response, timeout)
 }
 
 // sendXrtCommandRequest sends command request to XRT
-func (c *Client) sendXrtCommandRequest(ctx context.Context, requestId string, request interface{}, response interface{}) errors.EdgeX {
+func (c *Client) sendXrtCommandRequest(ctx context.Context, op string, requestId string, request interface{}, response interface{}) errors.EdgeX {
 	if c.clientOptions == nil || c.clientOptions.CommandOptions == nil {
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "please provide CommandOptions for the command request", nil)
 	}
-	return c.sendXrtRequestWithTimeout(ctx, c.clientOptions.CommandTopic, requestId, request, response, c.responseTimeout)
+	commandTopic := c.clientOptions.CommandTopic
+	if c.nodeID != "" {
+		if c.nodeCommandTopic == "" {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("please provide a CommandTopicTemplate for the command request to node %s", c.nodeID), nil)
+		}
+		commandTopic = c.nodeCommandTopic
+	}
+	return c.sendXrtRequestWithTimeout(ctx, op, commandTopic, requestId, request, response, c.responseTimeout)
+}
+
+// sendXrtRequestWithTimeout sends the request to XRT and decodes the reply into response. Concurrent identical read
+// requests share one XRT request if CollapseReads is enabled.
+func (c *Client) sendXrtRequestWithTimeout(ctx context.Context, op string, requestTopic string, requestId string, request interface{}, response interface{}, responseTimeout time.Duration) errors.EdgeX {
+	return c.sendXrtOp(ctx, c.callerOf(ctx), op, requestTopic, requestId, request, response, responseTimeout, false)
+}
+
+// sendXrtOp sends the request of the op on behalf of caller within the span of the op, decodes the reply into response
+// unless it is nil and audits the outcome. replayed is set for the operations replayed by the Outbox, which are never
+// queued again.
+func (c *Client) sendXrtOp(ctx context.Context, caller string, op string, requestTopic string, requestId string, request any, response any, responseTimeout time.Duration, replayed bool) errors.EdgeX {
+	if err := c.checkSupported(op); err != nil {
+		return err
+	}
+	start := time.Now()
+	ctx, span := c.startSpan(ctx, op, requestTopic, requestId, request)
+	attempts := newAttemptLog(requestId)
+	send := func(ctx context.Context) ([]byte, errors.EdgeX) {
+		return c.sendXrtRequestWithRetry(ctx, op, requestTopic, requestId, request, responseTimeout, attempts)
+	}
+
+	var reply []byte
+	var err errors.EdgeX
+	switch {
+	case c.flights != nil && IsReadOnlyOp(op):
+		reply, err = c.sendCollapsed(ctx, op, requestTopic, request, send)
+	case c.outbox != nil && IsMutatingOp(op) && !replayed:
+		reply, err = c.sendOrQueue(ctx, op, requestTopic, requestId, request, responseTimeout, send)
+	default:
+		reply, err = send(ctx)
+	}
+	if reply != nil && response != nil {
+		decodeErr := c.codec.Unmarshal(reply, response)
+		if decodeErr != nil {
+			err = errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to %s decoding command response", c.codec.Name()), decodeErr)
+		}
+	}
+	endSpan(span, err)
+	// a queued operation is audited once it has been replayed, and a replay which couldn't send it is tried again
+	if !stdErrors.Is(err, ErrQueued) && !(replayed && requestNotSent(err)) {
+		c.audit(caller, op, requestTopic, requestId, attempts.retried(), request, start, err)
+	}
+	return err
 }
 
-func (c *Client) sendXrtRequestWithTimeout(ctx context.Context, requestTopic string, requestId string, request interface{}, response interface{}, responseTimeout time.Duration) errors.EdgeX {
+// sendXrtRequestWithRetry sends the request to XRT and returns the reply, which is also returned along with the error
+// if XRT reports an error result. Requests covered by the RetryPolicy are sent again with a fresh requestId while the
+// failure is retryable and attempts are left; the requestIds of the retries are added to attempts, which may be nil.
+func (c *Client) sendXrtRequestWithRetry(ctx context.Context, op string, requestTopic string, requestId string, request any, responseTimeout time.Duration, attempts *attemptLog) ([]byte, errors.EdgeX) {
+	policy := c.retryPolicy(ctx, op)
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		reply, err := c.sendXrtRequestAttempt(ctx, op, requestTopic, requestId, request, responseTimeout)
+		c.observeRequest(op, start, err)
+		c.observeHealth(reply != nil, err)
+		if err == nil || policy == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return reply, err
+		}
+
+		backoff := policy.backoff(attempt)
+		c.lc.Debugf("XRT request %s failed on attempt %d/%d, retrying in %v: %v", op, attempt, policy.MaxAttempts, backoff, err)
+		if waitErr := sleepContext(ctx, backoff); waitErr != nil {
+			return nil, errors.NewCommonEdgeX(errors.Kind(waitErr), fmt.Sprintf("gave up retrying XRT request %s", op), waitErr)
+		}
+
+		var idErr errors.EdgeX
+		request, requestId, idErr = withNewRequestId(request)
+		if idErr != nil {
+			return nil, errors.NewCommonEdgeXWrapper(idErr)
+		}
+		attempts.add(requestId)
+		spanFromContext(ctx).AddEvent(EventRetry, map[string]any{AttributeAttempt: attempt + 1, AttributeRequestId: requestId, "error": err.Error()})
+	}
+}
+
+// sendXrtRequestAttempt sends the request to XRT once through the interceptors and checks the result of the reply
+func (c *Client) sendXrtRequestAttempt(ctx context.Context, op string, requestTopic string, requestId string, request any, responseTimeout time.Duration) ([]byte, errors.EdgeX) {
 	if c.replyTopicManager == nil {
-		return errors.NewCommonEdgeX(errors.KindContractInvalid, "replyTopic is required for sending XRT request", nil)
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "replyTopic is required for sending XRT request", nil)
 	}
 
-	jsonData, err := json.Marshal(request)
+	data, err := c.encodeRequest(request)
 	if err != nil {
-		return errors.NewCommonEdgeXWrapper(err)
+		return nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	// Before publishing the request, we should create responseChan to receive the response from XRT
-	c.replyTopicManager.RequestMap.Add(requestId, 1)
+	call := &Call{Op: op, Topic: requestTopic, RequestId: requestId, Request: data, Timeout: responseTimeout}
+	replies, edgexErr := c.invoke(ctx, call, c.roundTrip)
+	if edgexErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	if len(replies) == 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("no reply returned for XRT request %s", call.RequestId), nil)
+	}
+	cmdResponseBytes := replies[0]
+	if nodeErr := c.checkReplyNode(op, requestId, cmdResponseBytes); nodeErr != nil {
+		return nil, nodeErr
+	}
 
-	err = c.messageBus.PublishBinaryData(jsonData, requestTopic)
+	// handle error result from the XRT
+	var commonResponse xrtmodels.CommonResponse
+	err = c.codec.Unmarshal(cmdResponseBytes, &commonResponse)
 	if err != nil {
-		c.replyTopicManager.RequestMap.Delete(requestId)
-		return errors.NewCommonEdgeX(errors.Kind(err), "failed to send the XRT request", err)
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to %s decoding command response", c.codec.Name()), err)
 	}
+	if resultErr := commonResponse.Result.Error(); resultErr != nil {
+		return cmdResponseBytes, newXRTError(resultStatus(c.codec, cmdResponseBytes), resultErr.Error(), op, requestId)
+	}
+	return cmdResponseBytes, nil
+}
 
-	cmdResponseBytes, err := FetchXRTResponse(ctx, requestId, c.replyTopicManager.RequestMap, responseTimeout)
-	if err != nil {
-		return errors.NewCommonEdgeXWrapper(err)
+// roundTrip is the terminal Invoker of single-reply calls, it publishes the request and waits for the reply
+func (c *Client) roundTrip(ctx context.Context, call *Call) ([][]byte, errors.EdgeX) {
+	// Don't publish the request if the caller has already given up on it
+	if ctx.Err() != nil {
+		return nil, contextError(ctx)
+	}
+	if c.dryRunEnabled() {
+		return c.dryRun(call, c.injectTraceParent(ctx, call.Request))
 	}
 
-	err = json.Unmarshal(cmdResponseBytes, response)
-	if err != nil {
-		return errors.NewCommonEdgeX(errors.KindServerError, "failed to JSON decoding command response: %v", err)
+	record, edgexErr := c.breaker.allow(call.Topic)
+	if edgexErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	release, edgexErr := c.limiter.acquire(ctx, call.Op, call.Topic)
+	if edgexErr != nil {
+		record(edgexErr)
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
 	}
+	defer release()
 
-	// handle error result from the XRT
-	var commonResponse xrtmodels.CommonResponse
-	err = json.Unmarshal(cmdResponseBytes, &commonResponse)
+	// Before publishing the request, we should create responseChan to receive the response from XRT
+	c.replyTopicManager.RequestMap.Add(call.RequestId, 1)
+
+	err := c.messageBus.PublishBinaryData(c.injectTraceParent(ctx, call.Request), call.Topic)
 	if err != nil {
-		return errors.NewCommonEdgeX(errors.KindServerError, "failed to JSON decoding command response: %v", err)
+		c.replyTopicManager.RequestMap.Delete(call.RequestId)
+		edgexErr = newPublishError(err)
+		record(edgexErr)
+		return nil, edgexErr
 	}
-	if commonResponse.Result.Error() != nil {
-		return errors.NewCommonEdgeXWrapper(commonResponse.Result.Error())
+
+	cmdResponseBytes, edgexErr := FetchXRTResponse(ctx, call.RequestId, c.replyTopicManager.RequestMap, call.Timeout)
+	record(edgexErr)
+	if edgexErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
 	}
-	return nil
+	spanFromContext(ctx).AddEvent(EventReply, map[string]any{AttributeRequestId: call.RequestId, AttributeTopic: c.replyTopic})
+	return [][]byte{cmdResponseBytes}, nil
 }
 
-// sendXrtRequestWithSubTimeout publish the xrt request and wait for responses from multiple xrt nodes for the specific subscribe timeout
-func (c *Client) sendXrtRequestWithSubTimeout(ctx context.Context, requestTopic string, requestId string, request any,
-	response any, subscribeTimeout time.Duration) errors.EdgeX {
+// sendXrtRequestWithSubTimeout publish the xrt request and stream the responses from multiple xrt nodes, decoded to T, until the specific subscribe timeout
+// elapses or the stop condition matches. The request passes the interceptors, which see the replies once the stream has ended.
+func sendXrtRequestWithSubTimeout[T any](ctx context.Context, c *Client, op string, requestTopic string, requestId string, request any,
+	subscribeTimeout time.Duration, stop StopCondition[T]) (<-chan T, errors.EdgeX) {
 	if c.replyTopicManager == nil {
-		return errors.NewCommonEdgeX(errors.KindContractInvalid, "replyTopic is required for sending XRT request", nil)
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "replyTopic is required for sending XRT request", nil)
+	}
+	if err := c.checkSupported(op); err != nil {
+		return nil, err
 	}
 
-	jsonData, err := json.Marshal(request)
+	ctx, span := c.startSpan(ctx, op, requestTopic, requestId, request)
+	data, err := c.codec.Marshal(request)
 	if err != nil {
-		return errors.NewCommonEdgeXWrapper(err)
+		edgexErr := errors.NewCommonEdgeXWrapper(err)
+		endSpan(span, edgexErr)
+		return nil, edgexErr
+	}
+
+	call := &Call{Op: op, Topic: requestTopic, RequestId: requestId, Request: data, Timeout: subscribeTimeout, MultiReply: true}
+	forwarder := newReplyForwarder(stop, c.codec, c.lc)
+	// published receives the outcome of publishing the request, or the outcome of the interceptors if one of them
+	// returns without calling next
+	published := make(chan errors.EdgeX, 1)
+
+	go func() {
+		defer close(forwarder.replies)
+
+		invoked := false
+		terminal := func(ctx context.Context, call *Call) ([][]byte, errors.EdgeX) {
+			invoked = true
+			return multiReplyRoundTrip(ctx, c, call, forwarder, published)
+		}
+		start := time.Now()
+		replies, edgexErr := c.invoke(ctx, call, terminal)
+		c.observeRequest(call.Op, start, edgexErr)
+		c.observeHealth(len(replies) > 0, edgexErr)
+		endSpan(span, edgexErr)
+		if invoked {
+			if edgexErr != nil {
+				c.lc.Debugf("XRT request %s, requestId: %s, ended with error: %v", call.Op, call.RequestId, edgexErr)
+			}
+			return
+		}
+
+		// an interceptor short-circuited the request, stream the replies it returned instead
+		published <- edgexErr
+		if edgexErr != nil {
+			return
+		}
+		subCtx, cancel := context.WithTimeout(ctx, call.Timeout)
+		defer cancel()
+		for _, reply := range replies {
+			if !forwarder.forward(subCtx, reply) {
+				return
+			}
+		}
+	}()
+
+	if edgexErr := <-published; edgexErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	return forwarder.replies, nil
+}
+
+// multiReplyRoundTrip is the terminal Invoker of multi-reply calls. It reports the outcome of publishing the request
+// on published, forwards each reply as it arrives and returns all replies once the subscribe timeout has elapsed.
+func multiReplyRoundTrip[T any](ctx context.Context, c *Client, call *Call, forwarder *replyForwarder[T], published chan<- errors.EdgeX) ([][]byte, errors.EdgeX) {
+	// only the first attempt is reported, in case an interceptor calls next more than once
+	notify := func(err errors.EdgeX) {
+		select {
+		case published <- err:
+		default:
+		}
+	}
+
+	// Don't publish the request if the caller has already given up on it
+	if ctx.Err() != nil {
+		err := contextError(ctx)
+		notify(err)
+		return nil, err
 	}
+	if c.dryRunEnabled() {
+		replies, err := c.dryRun(call, c.injectTraceParent(ctx, call.Request))
+		notify(err)
+		return replies, err
+	}
+
+	release, limitErr := c.limiter.acquire(ctx, call.Op, call.Topic)
+	if limitErr != nil {
+		notify(limitErr)
+		return nil, limitErr
+	}
+	defer release()
 
 	// Before publishing the request, we should create responseChan to receive the response from XRT.
 	// Use MaxNodeCount as buffer capacity so replies from multiple XRT nodes don't get dropped.
@@ -229,20 +512,28 @@ func (c *Client) sendXrtRequestWithSubTimeout(ctx context.Context, requestTopic
 	if c.clientOptions != nil && c.clientOptions.DiscoveryOptions != nil && c.clientOptions.MaxNodeCount > 0 {
 		maxNodeCount = c.clientOptions.MaxNodeCount
 	}
-	c.replyTopicManager.RequestMap.Add(requestId, maxNodeCount)
+	c.replyTopicManager.RequestMap.Add(call.RequestId, maxNodeCount)
 
-	err = c.messageBus.PublishBinaryData(jsonData, requestTopic)
+	err := c.messageBus.PublishBinaryData(c.injectTraceParent(ctx, call.Request), call.Topic)
 	if err != nil {
-		c.replyTopicManager.RequestMap.Delete(requestId)
-		return errors.NewCommonEdgeX(errors.Kind(err), "failed to send the XRT request", err)
-	}
-
-	edgexErr := FetchXRTResWithSubTimeout(ctx, requestId, c.replyTopicManager.RequestMap, subscribeTimeout, response)
-	if edgexErr != nil {
-		return errors.NewCommonEdgeXWrapper(edgexErr)
-	}
-
-	return nil
+		c.replyTopicManager.RequestMap.Delete(call.RequestId)
+		edgexErr := newPublishError(err)
+		notify(edgexErr)
+		return nil, edgexErr
+	}
+	notify(nil)
+
+	var replies [][]byte
+	edgexErr := receiveXRTReplies(ctx, call.RequestId, c.replyTopicManager.RequestMap, call.Timeout, func(subCtx context.Context, reply []byte) bool {
+		if nodeErr := c.checkReplyNode(call.Op, call.RequestId, reply); nodeErr != nil {
+			c.lc.Warnf("ignoring reply: %v", nodeErr)
+			return true
+		}
+		replies = append(replies, reply)
+		spanFromContext(ctx).AddEvent(EventReply, map[string]any{AttributeRequestId: call.RequestId, AttributeTopic: c.replyTopic})
+		return forwarder.forward(subCtx, reply)
+	})
+	return replies, edgexErr
 }
 
 // initCommandDiscoverySubscription initializes the CommandOptions.DiscoveryTopic subscription
@@ -261,6 +552,7 @@ func (c *Client) initCommandDiscoverySubscription(clientOptions *ClientOptions,
 	}
 	c.commandDiscoveryHandlerID = handlerID
 	c.commandDiscoveryTopicManager = manager
+	c.observeTopic(manager)
 	return nil
 }
 
@@ -280,6 +572,7 @@ func (c *Client) initDiscoverySubscription(clientOptions *ClientOptions, lc logg
 	}
 	c.discoveryHandlerID = handlerID
 	c.discoveryTopicManager = manager
+	c.observeTopic(manager)
 	return nil
 }
 
@@ -299,13 +592,46 @@ func (c *Client) initStatusSubscription(clientOptions *ClientOptions, lc logger.
 	}
 	c.statusHandlerID = handlerID
 	c.statusTopicManager = manager
+	c.observeTopic(manager)
 	return nil
 }
 
+// observeTopic registers the Metrics of the client with the topic manager if they implement topicmgr.Observer
+func (c *Client) observeTopic(manager observedTopicManager) {
+	if c.clientOptions == nil {
+		return
+	}
+	observer, ok := c.clientOptions.Metrics.(topicmgr.Observer)
+	if !ok {
+		return
+	}
+	id, err := manager.RegisterObserver(observer)
+	if err != nil {
+		c.lc.Warnf("failed to register the metrics observer: %v", err)
+		return
+	}
+	c.observations = append(c.observations, topicObservation{manager: manager, id: id})
+}
+
 func (c *Client) Close() errors.EdgeX {
 	// Note: We don't call c.messageBus.Disconnect() here because the messageBus client may be used by other xrt clients.
 	// The disconnect should be handled by the code that created the messageBus client.
 
+	// node views share the subscriptions of the client they were created from
+	if c.nodeID != "" {
+		return nil
+	}
+
+	if c.outbox != nil {
+		c.outbox.detach(c)
+		c.outbox = nil
+	}
+
+	for _, observation := range c.observations {
+		observation.manager.UnregisterObserver(observation.id)
+	}
+	c.observations = nil
+
 	if c.replyTopicManager != nil {
 		topicmgr.TmPool.ReleaseTopicManager(c.replyTopic)
 		c.replyTopicManager = nil
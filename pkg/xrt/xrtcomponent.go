@@ -22,7 +22,7 @@ func (c *Client) UpdateLuaScript(ctx context.Context, luaScript string) errors.E
 	request := xrtmodels.NewComponentUpdateRequest(luaTransformComponent, clientName, config)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtCommandRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtCommandRequest(ctx, OpComponentUpdate, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to update the Lua script to Lua transform component", err)
 	}
@@ -30,22 +30,42 @@ func (c *Client) UpdateLuaScript(ctx context.Context, luaScript string) errors.E
 }
 
 func (c *Client) DiscoverComponents(ctx context.Context, category string, subscribeTimeout time.Duration) ([]xrtmodels.MultiComponentsResponse, errors.EdgeX) {
+	replies, err := c.DiscoverComponentsStream(ctx, category, subscribeTimeout, nil)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	response := make([]xrtmodels.MultiComponentsResponse, 0)
+	for reply := range replies {
+		response = append(response, reply)
+	}
+	// the stream also ends when ctx is done, in which case the replies received so far are incomplete
+	if ctx.Err() != nil {
+		ctxErr := contextError(ctx)
+		return nil, errors.NewCommonEdgeX(errors.Kind(ctxErr), "failed to discover the xrt components", ctxErr)
+	}
+	return response, nil
+}
+
+// DiscoverComponentsStream discovers the xrt components like DiscoverComponents, but sends the reply of each xrt node on
+// the returned channel as soon as it arrives. The channel is closed once subscribeTimeout elapses, stop reports true or
+// ctx is done. stop may be nil, or built with StopAfter and StopWhen.
+func (c *Client) DiscoverComponentsStream(ctx context.Context, category string, subscribeTimeout time.Duration,
+	stop func(received int, reply xrtmodels.MultiComponentsResponse) bool) (<-chan xrtmodels.MultiComponentsResponse, errors.EdgeX) {
 	request := xrtmodels.NewComponentDiscoverRequest(clientName, category)
-	var response []xrtmodels.MultiComponentsResponse
 
-	err := c.sendXrtRequestWithSubTimeout(ctx, c.requestTopic, request.RequestId, request, &response, subscribeTimeout)
+	replies, err := sendXrtRequestWithSubTimeout(ctx, c, OpComponentDiscover, c.requestTopic, request.RequestId, request, subscribeTimeout, stop)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to discover the xrt components", err)
 	}
-
-	return response, nil
+	return replies, nil
 }
 
 func (c *Client) UpdateComponent(ctx context.Context, name string, config map[string]any) errors.EdgeX {
 	request := xrtmodels.NewComponentUpdateRequest(name, clientName, config)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtCommandRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtCommandRequest(ctx, OpComponentUpdate, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to update the component", err)
 	}
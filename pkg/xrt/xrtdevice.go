@@ -20,7 +20,7 @@ func (c *Client) AllDevices(ctx context.Context) ([]string, errors.EdgeX) {
 	request := xrtmodels.NewAllDevicesRequest(clientName)
 	var response xrtmodels.MultiDevicesResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpDeviceList, request.RequestId, request, &response)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to query device list", err)
 	}
@@ -31,7 +31,7 @@ func (c *Client) DeviceByName(ctx context.Context, name string) (xrtmodels.Devic
 	request := xrtmodels.NewDeviceGetRequest(name, clientName)
 	var response xrtmodels.DeviceResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpDeviceGet, request.RequestId, request, &response)
 	if err != nil {
 		return xrtmodels.DeviceInfo{}, errors.NewCommonEdgeX(errors.Kind(err), "failed to query device", err)
 	}
@@ -46,7 +46,7 @@ func (c *Client) AddDevice(ctx context.Context, device dtos.Device) errors.EdgeX
 	request := xrtmodels.NewDeviceAddRequest(xrtDevice, clientName)
 	var response xrtmodels.CommonResponse
 
-	err = c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err = c.sendXrtRequest(ctx, OpDeviceAdd, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to add device", err)
 	}
@@ -61,7 +61,7 @@ func (c *Client) UpdateDevice(ctx context.Context, device dtos.Device) errors.Ed
 	request := xrtmodels.NewDeviceUpdateRequest(xrtDevice, clientName)
 	var response xrtmodels.CommonResponse
 
-	err = c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err = c.sendXrtRequest(ctx, OpDeviceUpdate, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to update device", err)
 	}
@@ -72,7 +72,7 @@ func (c *Client) DeleteDeviceByName(ctx context.Context, name string) errors.Edg
 	request := xrtmodels.NewDeviceDeleteRequest(name, clientName)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpDeviceDelete, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to delete device %s", name), err)
 	}
@@ -88,7 +88,7 @@ func (c *Client) AddDiscoveredDevice(ctx context.Context, device dtos.Device) er
 	request := xrtmodels.NewDiscoveredDeviceAddRequest(xrtDevice, clientName)
 	var response xrtmodels.CommonResponse
 
-	err = c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err = c.sendXrtRequest(ctx, OpDeviceAddDiscovered, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to add discovered device", err)
 	}
@@ -127,7 +127,7 @@ func (c *Client) ScanDeviceWithResult(ctx context.Context, device dtos.Device, o
 		request.DeviceName, request.ProfileName, request.Options)
 	var response scanDeviceResponse
 
-	err = c.sendXrtRequestWithTimeout(ctx, c.requestTopic, request.RequestId, request, &response, timeout)
+	err = c.sendXrtRequestWithTimeout(ctx, OpDeviceScan, c.requestTopic, request.RequestId, request, &response, timeout)
 	if err != nil {
 		return "", errors.NewCommonEdgeX(errors.Kind(err), "failed to scan device", err)
 	}
@@ -138,7 +138,7 @@ func (c *Client) ReadDeviceResources(ctx context.Context, deviceName string, res
 	request := xrtmodels.NewDeviceResourceGetRequest(deviceName, clientName, resourceNames)
 	var response xrtmodels.MultiResourcesResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpDeviceRead, request.RequestId, request, &response)
 	if err != nil {
 		return xrtmodels.MultiResourcesResult{}, errors.NewCommonEdgeX(errors.Kind(err), "failed to read device resources", err)
 	}
@@ -149,9 +149,44 @@ func (c *Client) WriteDeviceResources(ctx context.Context, deviceName string, re
 	request := xrtmodels.NewDeviceResourceSetRequest(deviceName, clientName, resourceValuePairs, options)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpDeviceWrite, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to write device resources", err)
 	}
 	return nil
 }
+
+// AddDeviceAsync sends the device:add request without waiting for the reply
+func (c *Client) AddDeviceAsync(ctx context.Context, device dtos.Device) *Future[struct{}] {
+	return asyncErr(ctx, func(ctx context.Context) errors.EdgeX {
+		return c.AddDevice(ctx, device)
+	})
+}
+
+// UpdateDeviceAsync sends the device:update request without waiting for the reply
+func (c *Client) UpdateDeviceAsync(ctx context.Context, device dtos.Device) *Future[struct{}] {
+	return asyncErr(ctx, func(ctx context.Context) errors.EdgeX {
+		return c.UpdateDevice(ctx, device)
+	})
+}
+
+// DeleteDeviceByNameAsync sends the device:delete request without waiting for the reply
+func (c *Client) DeleteDeviceByNameAsync(ctx context.Context, name string) *Future[struct{}] {
+	return asyncErr(ctx, func(ctx context.Context) errors.EdgeX {
+		return c.DeleteDeviceByName(ctx, name)
+	})
+}
+
+// ReadDeviceResourcesAsync sends the device:read request without waiting for the reply
+func (c *Client) ReadDeviceResourcesAsync(ctx context.Context, deviceName string, resourceNames []string) *Future[xrtmodels.MultiResourcesResult] {
+	return Async(ctx, func(ctx context.Context) (xrtmodels.MultiResourcesResult, errors.EdgeX) {
+		return c.ReadDeviceResources(ctx, deviceName, resourceNames)
+	})
+}
+
+// WriteDeviceResourcesAsync sends the device:write request without waiting for the reply
+func (c *Client) WriteDeviceResourcesAsync(ctx context.Context, deviceName string, resourceValuePairs, options map[string]any) *Future[struct{}] {
+	return asyncErr(ctx, func(ctx context.Context) errors.EdgeX {
+		return c.WriteDeviceResources(ctx, deviceName, resourceValuePairs, options)
+	})
+}
@@ -15,7 +15,7 @@ func (c *Client) AllDeviceProfiles(ctx context.Context) ([]string, errors.EdgeX)
 	request := xrtmodels.NewAllProfilesRequest(clientName)
 	var response xrtmodels.MultiProfilesResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpProfileList, request.RequestId, request, &response)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to query profile list", err)
 	}
@@ -26,7 +26,7 @@ func (c *Client) DeviceProfileByName(ctx context.Context, name string) (dtos.Dev
 	request := xrtmodels.NewProfileGetRequest(name, clientName)
 	var response xrtmodels.ProfileResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpProfileGet, request.RequestId, request, &response)
 	if err != nil {
 		return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.Kind(err), "failed to query profile", err)
 	}
@@ -37,7 +37,7 @@ func (c *Client) AddDeviceProfile(ctx context.Context, profile dtos.DeviceProfil
 	request := xrtmodels.NewProfileAddRequest(profile, clientName)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpProfileAdd, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to add profile", err)
 	}
@@ -48,7 +48,7 @@ func (c *Client) UpdateDeviceProfile(ctx context.Context, profile dtos.DevicePro
 	request := xrtmodels.NewProfileUpdateRequest(profile, clientName)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpProfileUpdate, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to update profile", err)
 	}
@@ -59,7 +59,7 @@ func (c *Client) DeleteDeviceProfileByName(ctx context.Context, name string) err
 	request := xrtmodels.NewProfileDeleteRequest(name, clientName)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpProfileDelete, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to delete profile %s", name), err)
 	}
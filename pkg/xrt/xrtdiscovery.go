@@ -18,7 +18,7 @@ func (c *Client) TriggerDiscovery(ctx context.Context) errors.EdgeX {
 	request := xrtmodels.NewDiscoveryRequest(clientName, c.clientOptions.ExtendedDiscoveryOptions)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtDiscoveryRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtDiscoveryRequest(ctx, OpDiscoveryTrigger, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to trigger discovery", err)
 	}
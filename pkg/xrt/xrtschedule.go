@@ -14,7 +14,7 @@ func (c *Client) AllSchedules(ctx context.Context) ([]string, errors.EdgeX) {
 	request := xrtmodels.NewAllSchedulesRequest(clientName)
 	var response xrtmodels.MultiSchedulesResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpScheduleList, request.RequestId, request, &response)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to query schedule list", err)
 	}
@@ -25,7 +25,7 @@ func (c *Client) AddSchedule(ctx context.Context, schedule xrtmodels.Schedule) e
 	request := xrtmodels.NewScheduleAddRequest(clientName, schedule)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpScheduleAdd, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to add schedule", err)
 	}
@@ -36,7 +36,7 @@ func (c *Client) DeleteScheduleByName(ctx context.Context, name string) errors.E
 	request := xrtmodels.NewScheduleDeleteRequest(name, clientName)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpScheduleDelete, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to delete schedule %s", name), err)
 	}
@@ -47,7 +47,7 @@ func (c *Client) ScheduleByName(ctx context.Context, name string) (xrtmodels.Sch
 	request := xrtmodels.NewScheduleReadRequest(name, clientName)
 	var response xrtmodels.ScheduleReadResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpScheduleRead, request.RequestId, request, &response)
 	if err != nil {
 		return xrtmodels.Schedule{}, errors.NewCommonEdgeX(errors.Kind(err), "failed to read schedule", err)
 	}
@@ -58,7 +58,7 @@ func (c *Client) UpdateSchedule(ctx context.Context, schedule xrtmodels.Schedule
 	request := xrtmodels.NewScheduleUpdateRequest(clientName, schedule)
 	var response xrtmodels.CommonResponse
 
-	err := c.sendXrtRequest(ctx, request.RequestId, request, &response)
+	err := c.sendXrtRequest(ctx, OpScheduleUpdate, request.RequestId, request, &response)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.Kind(err), "failed to update schedule", err)
 	}
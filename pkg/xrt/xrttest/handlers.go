@@ -0,0 +1,320 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrttest
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+)
+
+// request is the subset of the XRT management request understood by the fake node. Entity fields carry either
+// the entity name (get/delete requests) or the entity itself (add/update requests).
+type request struct {
+	Client     string         `json:"client"`
+	RequestId  string         `json:"request_id"`
+	Op         string         `json:"op"`
+	Device     any            `json:"device"`
+	DeviceInfo map[string]any `json:"device_info"`
+	Profile    any            `json:"profile"`
+	Schedule   any            `json:"schedule"`
+	Resources  []string       `json:"resources"`
+	Values     map[string]any `json:"values"`
+	Component  string         `json:"component"`
+	Config     map[string]any `json:"config"`
+	Category   string         `json:"category"`
+	Options    map[string]any `json:"options"`
+}
+
+// profileNameKey is the device info field referencing the device profile
+const profileNameKey = "profileName"
+
+func (x *XRT) dispatch(req request) map[string]any {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if !x.capabilities().Supports(req.Op) {
+		return errorResult(StatusInvalid, fmt.Sprintf("unsupported operation '%s'", req.Op))
+	}
+	switch req.Op {
+	case OpDeviceList:
+		return okResult(map[string]any{"devices": sortedKeys(x.devices)})
+	case OpDeviceGet:
+		return x.getDevice(req)
+	case OpDeviceAdd, OpDeviceAddDiscovered:
+		return x.addDevice(req)
+	case OpDeviceUpdate:
+		return x.updateDevice(req)
+	case OpDeviceDelete:
+		return x.deleteDevice(req)
+	case OpDeviceScan:
+		return x.scanDevice(req)
+	case OpDeviceRead:
+		return x.readResources(req)
+	case OpDeviceWrite:
+		return x.writeResources(req)
+	case OpDiscoveryTrigger:
+		return okResult(nil)
+	case OpProfileList:
+		return okResult(map[string]any{"profiles": sortedKeys(x.profiles)})
+	case OpProfileGet:
+		return getEntity(x.profiles, "profile", nameOf(req.Profile))
+	case OpProfileAdd:
+		return addEntity(x.profiles, "profile", req.Profile)
+	case OpProfileUpdate:
+		return updateEntity(x.profiles, "profile", req.Profile)
+	case OpProfileDelete:
+		return x.deleteProfile(req)
+	case OpScheduleList:
+		return okResult(map[string]any{"schedules": sortedKeys(x.schedules)})
+	case OpScheduleRead:
+		return getEntity(x.schedules, "schedule", nameOf(req.Schedule))
+	case OpScheduleAdd:
+		return addEntity(x.schedules, "schedule", req.Schedule)
+	case OpScheduleUpdate:
+		return updateEntity(x.schedules, "schedule", req.Schedule)
+	case OpScheduleDelete:
+		return deleteEntity(x.schedules, "schedule", nameOf(req.Schedule))
+	case OpComponentUpdate:
+		return x.updateComponent(req)
+	case OpComponentDiscover:
+		return x.discoverComponents(req)
+	default:
+		return errorResult(StatusInvalid, fmt.Sprintf("unsupported operation '%s'", req.Op))
+	}
+}
+
+func (x *XRT) getDevice(req request) map[string]any {
+	name := nameOf(req.Device)
+	device, ok := x.devices[name]
+	if !ok {
+		return notFound("device", name)
+	}
+	return okResult(map[string]any{"device": device})
+}
+
+func (x *XRT) addDevice(req request) map[string]any {
+	name := nameOf(req.Device)
+	if name == "" {
+		return errorResult(StatusInvalid, "device name is required")
+	}
+	if _, ok := x.devices[name]; ok {
+		return errorResult(StatusAlreadyExists, fmt.Sprintf("device '%s' already exists", name))
+	}
+	if result := x.validateDevice(req); result != nil {
+		return result
+	}
+	x.devices[name] = deviceInfo(req)
+	return okResult(nil)
+}
+
+func (x *XRT) updateDevice(req request) map[string]any {
+	name := nameOf(req.Device)
+	if _, ok := x.devices[name]; !ok {
+		return notFound("device", name)
+	}
+	if result := x.validateDevice(req); result != nil {
+		return result
+	}
+	x.devices[name] = deviceInfo(req)
+	return okResult(nil)
+}
+
+func (x *XRT) deleteDevice(req request) map[string]any {
+	name := nameOf(req.Device)
+	if _, ok := x.devices[name]; !ok {
+		return notFound("device", name)
+	}
+	delete(x.devices, name)
+	delete(x.resources, name)
+	return okResult(nil)
+}
+
+// scanDevice generates a profile for the device, named after the requested profile or the device itself
+func (x *XRT) scanDevice(req request) map[string]any {
+	deviceName := nameOf(req.Device)
+	if deviceName == "" {
+		return errorResult(StatusInvalid, "device name is required")
+	}
+	profileName := nameOf(req.Profile)
+	if profileName == "" {
+		profileName = deviceName + "-profile"
+	}
+	if _, ok := x.profiles[profileName]; !ok {
+		x.profiles[profileName] = map[string]any{"name": profileName}
+	}
+	if !x.capabilities().ScanReturnsProfile {
+		return okResult(nil)
+	}
+	return okResult(map[string]any{"profile": profileName})
+}
+
+func (x *XRT) readResources(req request) map[string]any {
+	name := nameOf(req.Device)
+	if _, ok := x.devices[name]; !ok {
+		return notFound("device", name)
+	}
+	values := x.resources[name]
+	readings := make(map[string]any)
+	if len(req.Resources) == 0 {
+		maps.Copy(readings, values)
+	}
+	for _, resource := range req.Resources {
+		value, ok := values[resource]
+		if !ok {
+			return notFound("resource", resource)
+		}
+		readings[resource] = value
+	}
+	return okResult(map[string]any{"readings": readings})
+}
+
+func (x *XRT) writeResources(req request) map[string]any {
+	name := nameOf(req.Device)
+	if _, ok := x.devices[name]; !ok {
+		return notFound("device", name)
+	}
+	if len(req.Values) == 0 {
+		return errorResult(StatusInvalid, "no resource values to write")
+	}
+	if x.resources[name] == nil {
+		x.resources[name] = make(map[string]any)
+	}
+	maps.Copy(x.resources[name], req.Values)
+	return okResult(nil)
+}
+
+func (x *XRT) deleteProfile(req request) map[string]any {
+	name := nameOf(req.Profile)
+	if _, ok := x.profiles[name]; !ok {
+		return notFound("profile", name)
+	}
+	for deviceName, device := range x.devices {
+		if device[profileNameKey] == name {
+			return errorResult(StatusInUse, fmt.Sprintf("profile '%s' is in use by device '%s'", name, deviceName))
+		}
+	}
+	delete(x.profiles, name)
+	return okResult(nil)
+}
+
+func (x *XRT) updateComponent(req request) map[string]any {
+	component, ok := x.components[req.Component]
+	if !ok {
+		return notFound("component", req.Component)
+	}
+	if component.Config == nil {
+		component.Config = make(map[string]any)
+	}
+	maps.Copy(component.Config, req.Config)
+	x.components[req.Component] = component
+	return okResult(nil)
+}
+
+func (x *XRT) discoverComponents(req request) map[string]any {
+	components := make([]Component, 0, len(x.components))
+	for _, name := range sortedKeys(x.components) {
+		component := x.components[name]
+		if req.Category == "" || component.Category == req.Category {
+			components = append(components, component)
+		}
+	}
+	result := okResult(map[string]any{"node": x.options.NodeID, "components": components})
+	if x.options.Version != "" {
+		result["version"] = x.options.Version
+	}
+	return result
+}
+
+// capabilities returns the capabilities of the emulated XRT version
+func (x *XRT) capabilities() xrt.Capabilities {
+	version, err := xrt.ParseVersion(x.options.Version)
+	if err != nil {
+		version = xrt.XRT22
+	}
+	return xrt.CapabilitiesOf(version)
+}
+
+// validateDevice rejects devices referencing an unknown profile; discovered devices may be added without one
+func (x *XRT) validateDevice(req request) map[string]any {
+	profileName, _ := deviceInfo(req)[profileNameKey].(string)
+	if profileName == "" {
+		return nil
+	}
+	if _, ok := x.profiles[profileName]; !ok {
+		return errorResult(StatusInvalid, fmt.Sprintf("device profile '%s' not found", profileName))
+	}
+	return nil
+}
+
+// deviceInfo returns the device info of an add/update request, which is either sent alongside the device name
+// or as the device object itself
+func deviceInfo(req request) map[string]any {
+	if req.DeviceInfo != nil {
+		return req.DeviceInfo
+	}
+	if device, ok := req.Device.(map[string]any); ok {
+		return device
+	}
+	return map[string]any{}
+}
+
+func getEntity(entities map[string]map[string]any, kind string, name string) map[string]any {
+	entity, ok := entities[name]
+	if !ok {
+		return notFound(kind, name)
+	}
+	return okResult(map[string]any{kind: entity})
+}
+
+func addEntity(entities map[string]map[string]any, kind string, value any) map[string]any {
+	entity, ok := value.(map[string]any)
+	name := nameOf(value)
+	if !ok || name == "" {
+		return errorResult(StatusInvalid, fmt.Sprintf("%s with a name is required", kind))
+	}
+	if _, exists := entities[name]; exists {
+		return errorResult(StatusAlreadyExists, fmt.Sprintf("%s '%s' already exists", kind, name))
+	}
+	entities[name] = entity
+	return okResult(nil)
+}
+
+func updateEntity(entities map[string]map[string]any, kind string, value any) map[string]any {
+	entity, ok := value.(map[string]any)
+	name := nameOf(value)
+	if !ok || name == "" {
+		return errorResult(StatusInvalid, fmt.Sprintf("%s with a name is required", kind))
+	}
+	if _, exists := entities[name]; !exists {
+		return notFound(kind, name)
+	}
+	entities[name] = entity
+	return okResult(nil)
+}
+
+func deleteEntity(entities map[string]map[string]any, kind string, name string) map[string]any {
+	if _, ok := entities[name]; !ok {
+		return notFound(kind, name)
+	}
+	delete(entities, name)
+	return okResult(nil)
+}
+
+func notFound(kind string, name string) map[string]any {
+	return errorResult(StatusNotFound, fmt.Sprintf("%s '%s' not found", kind, name))
+}
+
+// nameOf returns the entity name of a request field holding either the name or the entity object
+func nameOf(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		name, _ := v["name"].(string)
+		return name
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrttest
+
+import (
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/internal/membus"
+)
+
+// MessageBus is an in-memory messaging.MessageClient. Published messages are delivered to every matching
+// subscription in the same process, so xrt.Client and a fake XRT node can talk to each other without a broker.
+// Topic filters support the MQTT '+' and '#' wildcards.
+type MessageBus = membus.MessageBus
+
+// NewMessageBus creates an in-memory message bus
+func NewMessageBus() *MessageBus {
+	return membus.NewMessageBus()
+}
+
+// TopicMatches reports whether the topic matches the MQTT topic filter, which may contain '+' and '#' wildcards
+func TopicMatches(filter string, topic string) bool {
+	return membus.TopicMatches(filter, topic)
+}
@@ -0,0 +1,372 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrttest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/codec"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// XRT management operations answered by the fake node
+const (
+	OpDeviceList          = xrt.OpDeviceList
+	OpDeviceGet           = xrt.OpDeviceGet
+	OpDeviceAdd           = xrt.OpDeviceAdd
+	OpDeviceUpdate        = xrt.OpDeviceUpdate
+	OpDeviceDelete        = xrt.OpDeviceDelete
+	OpDeviceAddDiscovered = xrt.OpDeviceAddDiscovered
+	OpDeviceScan          = xrt.OpDeviceScan
+	OpDeviceRead          = xrt.OpDeviceRead
+	OpDeviceWrite         = xrt.OpDeviceWrite
+	OpDiscoveryTrigger    = xrt.OpDiscoveryTrigger
+	OpProfileList         = xrt.OpProfileList
+	OpProfileGet          = xrt.OpProfileGet
+	OpProfileAdd          = xrt.OpProfileAdd
+	OpProfileUpdate       = xrt.OpProfileUpdate
+	OpProfileDelete       = xrt.OpProfileDelete
+	OpScheduleList        = xrt.OpScheduleList
+	OpScheduleRead        = xrt.OpScheduleRead
+	OpScheduleAdd         = xrt.OpScheduleAdd
+	OpScheduleUpdate      = xrt.OpScheduleUpdate
+	OpScheduleDelete      = xrt.OpScheduleDelete
+	OpComponentUpdate     = xrt.OpComponentUpdate
+	OpComponentDiscover   = xrt.OpComponentDiscover
+)
+
+// Result status codes returned by the fake node
+const (
+	StatusOK            = xrt.StatusOK
+	StatusNotFound      = xrt.StatusNotFound
+	StatusInUse         = xrt.StatusInUse
+	StatusAlreadyExists = xrt.StatusAlreadyExists
+	StatusInvalid       = xrt.StatusInvalid
+)
+
+// Options provides the config of a fake XRT node
+type Options struct {
+	// NodeID identifies the node in the result of its replies
+	NodeID string
+	// RequestTopic is the topic the node receives management requests on
+	RequestTopic string
+	// CommandTopic is an optional second request topic, used by xrt.Client for component requests
+	CommandTopic string
+	// ReplyTopic is the topic the node publishes replies to
+	ReplyTopic string
+	// StatusTopic is the topic PublishStatus publishes the status of the node to
+	StatusTopic string
+	// Version is the XRT version the node emulates and reports in its status and component discovery replies,
+	// defaults to 2.2
+	Version string
+	// Codec decodes the requests and encodes the replies, it must match the codec of the client. Defaults to codec.JSON.
+	Codec codec.Codec
+}
+
+// Fault overrides the normal handling of an operation
+type Fault struct {
+	// Status and Message, when Status is non-zero, are returned as an error result instead of handling the request
+	Status  int
+	Message string
+	// Delay postpones the reply
+	Delay time.Duration
+	// Drop suppresses the reply entirely, so the client runs into its response timeout
+	Drop bool
+	// Count limits the fault to the next Count requests of the operation, zero applies it until ClearFaults
+	Count int
+}
+
+// Component is a component hosted by the fake node
+type Component struct {
+	Name     string         `json:"name"`
+	Category string         `json:"category"`
+	Config   map[string]any `json:"config"`
+}
+
+// ReceivedRequest is a request received by the fake node
+type ReceivedRequest struct {
+	Topic     string
+	Op        string
+	RequestId string
+	Payload   []byte
+}
+
+// XRT is an in-memory stand-in for an Edge XRT node. It answers the MQTT management requests sent by xrt.Client
+// on the reply topic and keeps devices, profiles, schedules and components in memory.
+type XRT struct {
+	messageBus messaging.MessageClient
+	options    Options
+
+	mutex      sync.RWMutex
+	devices    map[string]map[string]any
+	resources  map[string]map[string]any
+	profiles   map[string]map[string]any
+	schedules  map[string]map[string]any
+	components map[string]Component
+	faults     map[string]Fault
+	received   []ReceivedRequest
+
+	cancelFunc context.CancelFunc
+	topics     []string
+}
+
+// NewXRT creates a fake XRT node on the given message bus. Call Start to begin answering requests.
+func NewXRT(messageBus messaging.MessageClient, options Options) *XRT {
+	return &XRT{
+		messageBus: messageBus,
+		options:    options,
+		devices:    make(map[string]map[string]any),
+		resources:  make(map[string]map[string]any),
+		profiles:   make(map[string]map[string]any),
+		schedules:  make(map[string]map[string]any),
+		components: make(map[string]Component),
+		faults:     make(map[string]Fault),
+	}
+}
+
+// Start subscribes to the request topics
+func (x *XRT) Start() errors.EdgeX {
+	if x.options.RequestTopic == "" || x.options.ReplyTopic == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "RequestTopic and ReplyTopic are required", nil)
+	}
+	topics := []string{x.options.RequestTopic}
+	if x.options.CommandTopic != "" && x.options.CommandTopic != x.options.RequestTopic {
+		topics = append(topics, x.options.CommandTopic)
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	messages := make(chan types.MessageEnvelope)
+	topicChannels := make([]types.TopicChannel, 0, len(topics))
+	for _, topic := range topics {
+		topicChannels = append(topicChannels, types.TopicChannel{Topic: topic, Messages: messages})
+	}
+	if err := x.messageBus.SubscribeBinaryData(topicChannels, make(chan error)); err != nil {
+		cancelFunc()
+		return errors.NewCommonEdgeX(errors.KindCommunicationError, "failed to subscribe to the request topics", err)
+	}
+	x.cancelFunc = cancelFunc
+	x.topics = topics
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-messages:
+				go x.handle(message)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop unsubscribes from the request topics
+func (x *XRT) Stop() {
+	if x.cancelFunc == nil {
+		return
+	}
+	x.cancelFunc()
+	_ = x.messageBus.Unsubscribe(x.topics...)
+	x.cancelFunc = nil
+	x.topics = nil
+}
+
+// PublishStatus publishes the status of the node and its components to the status topic, status is e.g. online or
+// offline
+func (x *XRT) PublishStatus(status string) errors.EdgeX {
+	if x.options.StatusTopic == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "StatusTopic is required to publish the status", nil)
+	}
+	x.mutex.RLock()
+	nodeStatus := xrt.NodeStatus{Node: x.options.NodeID, Status: status, Version: x.options.Version}
+	for _, name := range sortedKeys(x.components) {
+		nodeStatus.Components = append(nodeStatus.Components, xrt.NodeComponent{Name: name, Category: x.components[name].Category})
+	}
+	x.mutex.RUnlock()
+
+	data, err := codec.OrDefault(x.options.Codec).Marshal(nodeStatus)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the status", err)
+	}
+	if err = x.messageBus.PublishBinaryData(data, x.options.StatusTopic); err != nil {
+		return errors.NewCommonEdgeX(errors.KindCommunicationError, "failed to publish the status", err)
+	}
+	return nil
+}
+
+// SetFault makes the node apply the fault to every subsequent request of the operation
+func (x *XRT) SetFault(op string, fault Fault) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	x.faults[op] = fault
+}
+
+// ClearFaults restores the normal handling of all operations
+func (x *XRT) ClearFaults() {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	x.faults = make(map[string]Fault)
+}
+
+// AddComponent adds or replaces a component hosted by the node
+func (x *XRT) AddComponent(component Component) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	x.components[component.Name] = component
+}
+
+// Component returns the component with the given name
+func (x *XRT) Component(name string) (Component, bool) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	component, ok := x.components[name]
+	return component, ok
+}
+
+// SetResourceValue sets the value returned when the device resource is read
+func (x *XRT) SetResourceValue(deviceName string, resourceName string, value any) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if x.resources[deviceName] == nil {
+		x.resources[deviceName] = make(map[string]any)
+	}
+	x.resources[deviceName][resourceName] = value
+}
+
+// ResourceValue returns the last value written to or set for the device resource
+func (x *XRT) ResourceValue(deviceName string, resourceName string) (any, bool) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	value, ok := x.resources[deviceName][resourceName]
+	return value, ok
+}
+
+// Device returns the stored device info of the device with the given name
+func (x *XRT) Device(name string) (map[string]any, bool) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	device, ok := x.devices[name]
+	return device, ok
+}
+
+// DeviceNames returns the names of all devices, sorted
+func (x *XRT) DeviceNames() []string {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return sortedKeys(x.devices)
+}
+
+// ProfileNames returns the names of all device profiles, sorted
+func (x *XRT) ProfileNames() []string {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return sortedKeys(x.profiles)
+}
+
+// ScheduleNames returns the names of all schedules, sorted
+func (x *XRT) ScheduleNames() []string {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return sortedKeys(x.schedules)
+}
+
+// Requests returns the requests received so far, in arrival order
+func (x *XRT) Requests() []ReceivedRequest {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return append([]ReceivedRequest(nil), x.received...)
+}
+
+func (x *XRT) handle(message types.MessageEnvelope) {
+	payload, err := types.ConvertMsgPayloadToByteArray(message.ContentType, message.Payload)
+	if err != nil {
+		return
+	}
+	payloadCodec := codec.OrDefault(x.options.Codec)
+	var req request
+	if err = payloadCodec.Unmarshal(payload, &req); err != nil || req.RequestId == "" {
+		// not an XRT management request, real XRT ignores it as well
+		return
+	}
+
+	x.mutex.Lock()
+	x.received = append(x.received, ReceivedRequest{
+		Topic:     message.ReceivedTopic,
+		Op:        req.Op,
+		RequestId: req.RequestId,
+		Payload:   payload,
+	})
+	fault, faulty := x.faults[req.Op]
+	if faulty && fault.Count > 0 {
+		if fault.Count == 1 {
+			delete(x.faults, req.Op)
+		} else {
+			remaining := fault
+			remaining.Count--
+			x.faults[req.Op] = remaining
+		}
+	}
+	x.mutex.Unlock()
+
+	if faulty {
+		if fault.Drop {
+			return
+		}
+		if fault.Delay > 0 {
+			time.Sleep(fault.Delay)
+		}
+	}
+
+	var result map[string]any
+	if faulty && fault.Status != StatusOK {
+		result = errorResult(fault.Status, fault.Message)
+	} else {
+		result = x.dispatch(req)
+	}
+	if x.options.NodeID != "" {
+		result["node"] = x.options.NodeID
+	}
+
+	reply := map[string]any{
+		"client":     req.Client,
+		"request_id": req.RequestId,
+		"result":     result,
+	}
+	data, err := payloadCodec.Marshal(reply)
+	if err != nil {
+		return
+	}
+	_ = x.messageBus.PublishBinaryData(data, x.options.ReplyTopic)
+}
+
+func errorResult(status int, message string) map[string]any {
+	return map[string]any{
+		"status": status,
+		"error":  message,
+	}
+}
+
+// okResult returns a successful result carrying the given fields
+func okResult(fields map[string]any) map[string]any {
+	result := map[string]any{"status": StatusOK}
+	for k, v := range fields {
+		result[k] = v
+	}
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
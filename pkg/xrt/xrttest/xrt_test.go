@@ -0,0 +1,230 @@
+// Copyright (C) 2026 IOTech Ltd
+
+package xrttest_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt"
+	"github.com/IOTechSystems/go-mod-edge-connect-client/v4/pkg/xrt/xrttest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+const testResponseTimeout = 200 * time.Millisecond
+
+// newTestClient starts a fake node and returns it with a client talking to it, the topics start with prefix
+func newTestClient(t *testing.T, prefix string) (*xrt.Client, *xrttest.XRT) {
+	t.Helper()
+	bus := xrttest.NewMessageBus()
+	node := xrttest.NewXRT(bus, xrttest.Options{RequestTopic: prefix + "/request", ReplyTopic: prefix + "/reply"})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start fake XRT node: %v", err)
+	}
+	t.Cleanup(node.Stop)
+
+	client, err := xrt.NewXrtClient(context.Background(), bus, prefix+"/request", prefix+"/reply", testResponseTimeout,
+		logger.NewMockClient(), nil)
+	if err != nil {
+		t.Fatalf("failed to create xrt client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client.(*xrt.Client), node
+}
+
+func addTestDevice(t *testing.T, client *xrt.Client, name string) {
+	t.Helper()
+	ctx := context.Background()
+	profile := dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile"}}
+	if err := client.AddDeviceProfile(ctx, profile); err != nil && !stdErrors.Is(err, xrt.ErrAlreadyExists) {
+		t.Fatalf("AddDeviceProfile failed: %v", err)
+	}
+	if err := client.AddDevice(ctx, dtos.Device{Name: name, ProfileName: "profile"}); err != nil {
+		t.Fatalf("AddDevice failed: %v", err)
+	}
+}
+
+func TestTopicMatches(t *testing.T) {
+	tests := []struct {
+		filter string
+		topic  string
+		match  bool
+	}{
+		{"a/b/c", "a/b/c", true},
+		{"a/b/c", "a/b", false},
+		{"a/b", "a/b/c", false},
+		{"a/+/c", "a/b/c", true},
+		{"a/+/c", "a/b/d", false},
+		{"a/+", "a/b/c", false},
+		{"a/#", "a/b/c", true},
+		{"a/#", "a", true},
+		{"a/#", "b/c", false},
+		{"#", "a/b", true},
+	}
+	for _, tt := range tests {
+		if got := xrttest.TopicMatches(tt.filter, tt.topic); got != tt.match {
+			t.Errorf("TopicMatches(%q, %q) = %v, want %v", tt.filter, tt.topic, got, tt.match)
+		}
+	}
+}
+
+func TestMessageBusDeliversInOrder(t *testing.T) {
+	bus := xrttest.NewMessageBus()
+	messages := make(chan types.MessageEnvelope)
+	if err := bus.SubscribeBinaryData([]types.TopicChannel{{Topic: "bus/+", Messages: messages}}, nil); err != nil {
+		t.Fatalf("SubscribeBinaryData failed: %v", err)
+	}
+	for _, payload := range []string{"1", "2", "3"} {
+		if err := bus.PublishBinaryData([]byte(payload), "bus/topic"); err != nil {
+			t.Fatalf("PublishBinaryData failed: %v", err)
+		}
+	}
+	_ = bus.PublishBinaryData([]byte("other"), "other/topic")
+
+	for _, want := range []string{"1", "2", "3"} {
+		select {
+		case message := <-messages:
+			if string(message.Payload.([]byte)) != want || message.ReceivedTopic != "bus/topic" {
+				t.Fatalf("received %q on %s, want %q on bus/topic", message.Payload, message.ReceivedTopic, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message %s not delivered", want)
+		}
+	}
+	select {
+	case message := <-messages:
+		t.Fatalf("unexpected message %q on %s", message.Payload, message.ReceivedTopic)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/roundtrip")
+	ctx := context.Background()
+	addTestDevice(t, client, "device")
+
+	if !slices.Equal(node.DeviceNames(), []string{"device"}) {
+		t.Fatalf("node has devices %v, want [device]", node.DeviceNames())
+	}
+	names, err := client.AllDevices(ctx)
+	if err != nil || !slices.Equal(names, []string{"device"}) {
+		t.Fatalf("AllDevices returned %v, %v", names, err)
+	}
+	device, err := client.DeviceByName(ctx, "device")
+	if err != nil || device.ProfileName != "profile" {
+		t.Fatalf("DeviceByName returned %+v, %v", device, err)
+	}
+	if err = client.DeleteDeviceByName(ctx, "device"); err != nil {
+		t.Fatalf("DeleteDeviceByName failed: %v", err)
+	}
+	if _, err = client.DeviceByName(ctx, "device"); !stdErrors.Is(err, xrt.ErrNotFound) {
+		t.Fatalf("DeviceByName of a deleted device returned %v, want ErrNotFound", err)
+	}
+
+	var ops []string
+	for _, request := range node.Requests() {
+		ops = append(ops, request.Op)
+	}
+	want := []string{xrttest.OpProfileAdd, xrttest.OpDeviceAdd, xrttest.OpDeviceList, xrttest.OpDeviceGet, xrttest.OpDeviceDelete, xrttest.OpDeviceGet}
+	if !slices.Equal(ops, want) {
+		t.Fatalf("node received %v, want %v", ops, want)
+	}
+}
+
+func TestFaultStatus(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/status")
+	ctx := context.Background()
+	addTestDevice(t, client, "device")
+
+	node.SetFault(xrttest.OpDeviceGet, xrttest.Fault{Status: xrttest.StatusInUse, Message: "busy", Count: 1})
+	_, err := client.DeviceByName(ctx, "device")
+	var xrtErr *xrt.XRTError
+	if !stdErrors.Is(err, xrt.ErrInUse) || !stdErrors.As(err, &xrtErr) || xrtErr.Message != "busy" {
+		t.Fatalf("DeviceByName returned %v, want the injected error result", err)
+	}
+	if _, err = client.DeviceByName(ctx, "device"); err != nil {
+		t.Fatalf("DeviceByName failed after the fault was used up: %v", err)
+	}
+}
+
+func TestFaultPersistsUntilCleared(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/persist")
+	ctx := context.Background()
+
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Status: xrttest.StatusInvalid})
+	for range 2 {
+		if _, err := client.AllDevices(ctx); !stdErrors.Is(err, xrt.ErrValidation) {
+			t.Fatalf("AllDevices returned %v, want ErrValidation", err)
+		}
+	}
+	node.ClearFaults()
+	if _, err := client.AllDevices(ctx); err != nil {
+		t.Fatalf("AllDevices failed after ClearFaults: %v", err)
+	}
+}
+
+func TestFaultDropTimesOut(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/drop")
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true, Count: 1})
+
+	start := time.Now()
+	_, err := client.AllDevices(context.Background())
+	if !stdErrors.Is(err, xrt.ErrTimeout) || errors.Kind(err) != xrt.KindTimeout {
+		t.Fatalf("AllDevices returned %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed < testResponseTimeout {
+		t.Fatalf("AllDevices returned after %v, before the response timeout", elapsed)
+	}
+	if _, err = client.AllDevices(context.Background()); err != nil {
+		t.Fatalf("AllDevices failed after the dropped request: %v", err)
+	}
+}
+
+func TestFaultDelay(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/delay")
+	ctx := context.Background()
+
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Delay: testResponseTimeout / 4, Count: 1})
+	if _, err := client.AllDevices(ctx); err != nil {
+		t.Fatalf("AllDevices failed with a delay within the response timeout: %v", err)
+	}
+
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Delay: 2 * testResponseTimeout, Count: 1})
+	if _, err := client.AllDevices(ctx); !stdErrors.Is(err, xrt.ErrTimeout) {
+		t.Fatalf("AllDevices returned %v with a delay beyond the response timeout, want ErrTimeout", err)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/deadline")
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testResponseTimeout/4)
+	defer cancel()
+	_, err := client.AllDevices(ctx)
+	if !stdErrors.Is(err, context.DeadlineExceeded) || !stdErrors.Is(err, xrt.ErrTimeout) {
+		t.Fatalf("AllDevices returned %v, want the expired deadline", err)
+	}
+}
+
+func TestContextCanceled(t *testing.T) {
+	client, node := newTestClient(t, "xrttest/canceled")
+	node.SetFault(xrttest.OpDeviceList, xrttest.Fault{Drop: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(testResponseTimeout/4, cancel)
+	_, err := client.AllDevices(ctx)
+	if !stdErrors.Is(err, context.Canceled) || errors.Kind(err) != xrt.KindCanceled {
+		t.Fatalf("AllDevices returned %v of kind %s, want the cancellation", err, errors.Kind(err))
+	}
+	if stdErrors.Is(err, xrt.ErrPublishFailed) || errors.Kind(err) == errors.KindCommunicationError {
+		t.Fatalf("AllDevices reported the cancellation %v as a failure of the message bus", err)
+	}
+}